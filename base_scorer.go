@@ -1,7 +1,11 @@
 package judgeval
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"maps"
+	"strings"
 
 	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
 )
@@ -11,22 +15,98 @@ type BaseScorer interface {
 	GetScorerConfig() *models.ScorerConfig
 }
 
+// LocalScorer is a scorer that runs in-process instead of on the platform.
+// Local scorers are run with Evaluation.Run.
+type LocalScorer interface {
+	BaseScorer
+	Score(ctx context.Context, example *Example) (*ScorerResult, error)
+}
+
+// ScorerResult is the outcome of scoring one example with one scorer.
+type ScorerResult struct {
+	Name      string
+	Score     float64
+	Threshold float64
+	Success   bool
+	Reason    string
+	// Label is the category chosen by binary and categorical scorers.
+	Label string
+	// NormalizedScore is Score mapped onto 0-1 by the scorer's ScoreRange,
+	// with 1 always best.
+	NormalizedScore float64
+	Breakdown       map[string]any
+	Error           string
+}
+
+func (r *ScorerResult) toScorerData() map[string]any {
+	data := map[string]any{
+		"name":      r.Name,
+		"score":     r.Score,
+		"threshold": r.Threshold,
+		"success":   r.Success,
+	}
+	if r.Error == "" {
+		data["normalized_score"] = r.NormalizedScore
+	}
+	if r.Reason != "" {
+		data["reason"] = r.Reason
+	}
+	if r.Label != "" {
+		data["label"] = r.Label
+	}
+	if len(r.Breakdown) > 0 {
+		data["additional_metadata"] = r.Breakdown
+	}
+	if r.Error != "" {
+		data["error"] = r.Error
+	}
+	return data
+}
+
+// scoreForLabel maps a binary or categorical label to its score, matching
+// labels case-insensitively.
+func scoreForLabel(options map[string]float64, label string) (float64, bool) {
+	if score, ok := options[label]; ok {
+		return score, true
+	}
+	for k, score := range options {
+		if strings.EqualFold(k, label) {
+			return score, true
+		}
+	}
+	return 0, false
+}
+
+var ErrScorerNotFound = errors.New("scorer not found")
+
+// ScorerNotFoundError is returned when a named scorer does not exist in the
+// project. It matches ErrScorerNotFound with errors.Is.
+type ScorerNotFoundError struct {
+	Name      string
+	ScoreType APIScorerType
+}
+
+func (e *ScorerNotFoundError) Error() string {
+	return fmt.Sprintf("%s scorer '%s' not found", e.ScoreType, e.Name)
+}
+
+func (e *ScorerNotFoundError) Unwrap() error {
+	return ErrScorerNotFound
+}
+
 type apiScorer struct {
 	scoreType       APIScorerType
 	threshold       float64
 	name            string
 	strictMode      bool
 	model           string
+	resultType      ScorerResultType
+	scoreRange      ScoreRange
 	requiredParams  []string
 	additionalProps map[string]interface{}
 }
 
 func newAPIScorer(scoreType APIScorerType, threshold float64, name string, strictMode bool, model string, requiredParams []string) *apiScorer {
-	finalThreshold := threshold
-	if strictMode {
-		finalThreshold = 1.0
-	}
-
 	finalName := name
 	if finalName == "" {
 		finalName = scoreType.String()
@@ -34,10 +114,12 @@ func newAPIScorer(scoreType APIScorerType, threshold float64, name string, stric
 
 	return &apiScorer{
 		scoreType:       scoreType,
-		threshold:       finalThreshold,
+		threshold:       threshold,
 		name:            finalName,
 		strictMode:      strictMode,
 		model:           model,
+		resultType:      ScorerResultTypeNumeric,
+		scoreRange:      DefaultScoreRange,
 		requiredParams:  requiredParams,
 		additionalProps: make(map[string]interface{}),
 	}
@@ -47,17 +129,40 @@ func (s *apiScorer) GetName() string {
 	return s.name
 }
 
+func (s *apiScorer) GetScoreRange() ScoreRange {
+	return s.scoreRange
+}
+
+// effectiveThreshold is the threshold sent to the server. Strict mode
+// requires the best score on the scorer's range rather than a fixed 1.0.
+func (s *apiScorer) effectiveThreshold() float64 {
+	if s.strictMode {
+		return s.scoreRange.best()
+	}
+	return s.threshold
+}
+
 func (s *apiScorer) toScorerConfig(requiredParams []string) *models.ScorerConfig {
 	kwargs := make(map[string]interface{})
 	maps.Copy(kwargs, s.additionalProps)
+	s.scoreRange.toKwargs(kwargs)
+	if s.strictMode {
+		kwargs["strict_mode"] = true
+	}
 
-	return &models.ScorerConfig{
+	config := &models.ScorerConfig{
 		ScoreType:      s.scoreType.String(),
-		Threshold:      s.threshold,
+		Threshold:      s.effectiveThreshold(),
 		Name:           s.name,
 		RequiredParams: requiredParams,
 		Kwargs:         kwargs,
 		Model:          s.model,
-		ResultType:     "numeric",
+		ResultType:     s.resultType.String(),
+	}
+	// A zero threshold is meaningful for lower-is-better scorers but would be
+	// dropped by omitempty, so it is sent explicitly.
+	if config.Threshold == 0 {
+		config.AdditionalProperties = map[string]any{"threshold": 0.0}
 	}
+	return config
 }
@@ -20,15 +20,23 @@ type BaseTracer struct {
 	enableEvaluation bool
 	apiClient        *api.Client
 	serializer       SerializerFunc
-	tracer           trace.Tracer
+	recordStateDiff  bool
+	pricing          *pricingTable
+	// spanUpdateInterval enables periodic snapshots of open spans when
+	// positive.
+	spanUpdateInterval time.Duration
+	tagger             *traceTagger
+	offline            *OfflineParams
+	toolCalls          *toolCallIndex
+	tracer             trace.Tracer
 }
 
 func (b *BaseTracer) GetTracer() trace.Tracer {
 	return b.tracer
 }
 
-func (b *BaseTracer) Span(ctx context.Context, spanName string) (context.Context, trace.Span) {
-	ctx, span := b.tracer.Start(ctx, spanName)
+func (b *BaseTracer) Span(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	ctx, span := b.tracer.Start(ctx, spanName, opts...)
 	return ctx, span
 }
 
@@ -124,6 +132,26 @@ func (b *BaseTracer) SetSessionID(ctx context.Context, sessionID string) context
 	return contextWithSessionID(ctx, sessionID)
 }
 
+// StartAgent starts the entry-point span of a new agent named after
+// className. Spans started from the returned context, including nested
+// agents, carry the agent's ID and their parent agent's ID.
+func (b *BaseTracer) StartAgent(ctx context.Context, className string, instanceName string) (context.Context, trace.Span) {
+	agent := &agentContext{id: uuid.New().String()}
+	if parent, ok := agentFromContext(ctx); ok {
+		agent.parentID = parent.id
+	}
+
+	ctx, span := b.tracer.Start(contextWithAgent(ctx, agent), className)
+	span.SetAttributes(
+		attribute.Bool(AttributeKeysJudgmentIsAgentEntryPoint, true),
+		attribute.String(AttributeKeysJudgmentAgentClassName, className),
+	)
+	if instanceName != "" {
+		span.SetAttributes(attribute.String(AttributeKeysJudgmentAgentInstanceName, instanceName))
+	}
+	return ctx, span
+}
+
 func (b *BaseTracer) AsyncEvaluate(ctx context.Context, scorer BaseScorer, example *Example) {
 	if !b.enableEvaluation {
 		return
@@ -178,22 +206,65 @@ func (b *BaseTracer) AsyncTraceEvaluate(ctx context.Context, scorer BaseScorer)
 	span.SetAttributes(attribute.String(AttributeKeysPendingTraceEval, string(traceEvalJSON)))
 }
 
-func (b *BaseTracer) getSpanExporter(ctx context.Context) sdktrace.SpanExporter {
+func (b *BaseTracer) getSpanExporter(ctx context.Context, afterExport ...exportHook) sdktrace.SpanExporter {
 	if b.projectID != "" {
-		return NewJudgmentSpanExporter(ctx, b.buildEndpoint(), b.apiClient, b.projectID)
+		exporter := NewJudgmentSpanExporter(ctx, b.buildEndpoint(), b.apiClient, b.projectID)
+		if b.tagger != nil {
+			afterExport = append(afterExport, b.tagger.onExport)
+		}
+		if len(afterExport) == 0 {
+			return exporter
+		}
+		return &hookedSpanExporter{
+			SpanExporter: exporter,
+			afterExport:  afterExport,
+		}
 	}
 	logger.Error("Project not resolved; cannot create exporter, returning NoOpSpanExporter")
 	return NewNoOpSpanExporter()
 }
 
 func (b *BaseTracer) getSpanProcessor(ctx context.Context) sdktrace.SpanProcessor {
-	if b.projectID != "" {
-		exporter := b.getSpanExporter(ctx)
-		batchProcessor := sdktrace.NewBatchSpanProcessor(exporter)
-		return NewJudgmentSpanProcessor(batchProcessor, lifecycleSpanProcessors())
+	if b.projectID == "" {
+		logger.Error("Project not resolved; cannot create processor, returning NoOpSpanProcessor")
+		return NewNoOpSpanProcessor()
+	}
+
+	lifecycle := lifecycleSpanProcessors()
+	if b.toolCalls != nil {
+		lifecycle = append(lifecycle, b.toolCalls)
+	}
+	var exporter sdktrace.SpanExporter
+	if b.offline != nil {
+		exporter = b.getOfflineSpanExporter()
+	} else {
+		rootSpanRules := NewRootSpanRulesProcessor(b.apiClient)
+		exporter = b.getSpanExporter(ctx, rootSpanRules.onExport)
+		lifecycle = append(lifecycle, rootSpanRules)
+	}
+
+	batchProcessor := sdktrace.NewBatchSpanProcessor(exporter)
+	if b.spanUpdateInterval > 0 {
+		return NewJudgmentSpanProcessorWithUpdates(batchProcessor, lifecycle, b.spanUpdateInterval)
+	}
+	return NewJudgmentSpanProcessor(batchProcessor, lifecycle)
+}
+
+func (b *BaseTracer) getOfflineSpanExporter() sdktrace.SpanExporter {
+	exporter, err := NewFileSpanExporter(b.offline.Dir, getInt(b.offline.MaxFileSize, defaultOfflineMaxFileSize))
+	if err != nil {
+		logger.Error("Failed to create offline span exporter: %v", err)
+		return NewNoOpSpanExporter()
+	}
+	logger.Info("Offline mode: writing spans to %s", b.offline.Dir)
+	if b.tagger == nil {
+		return exporter
+	}
+	b.tagger.offline = exporter
+	return &hookedSpanExporter{
+		SpanExporter: exporter,
+		afterExport:  []exportHook{b.tagger.onExport},
 	}
-	logger.Error("Project not resolved; cannot create processor, returning NoOpSpanProcessor")
-	return NewNoOpSpanProcessor()
 }
 
 func (b *BaseTracer) buildEndpoint() string {
@@ -252,8 +323,8 @@ func (b *BaseTracer) createTraceEvaluationRun(scorer BaseScorer, traceID, spanID
 	return run
 }
 
-func (b *BaseTracer) StartSpan(ctx context.Context, spanName string) (context.Context, trace.Span) {
-	ctx, span := b.tracer.Start(ctx, spanName)
+func (b *BaseTracer) StartSpan(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	ctx, span := b.tracer.Start(ctx, spanName, opts...)
 	return ctx, span
 }
 
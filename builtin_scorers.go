@@ -1,71 +1,163 @@
 package judgeval
 
-import "github.com/JudgmentLabs/judgeval-go/internal/api/models"
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+)
 
 type BuiltInScorersFactory struct{}
 
-type FaithfulnessScorerParams struct {
-	Threshold  *float64
-	Name       *string
-	StrictMode *bool
-	Model      *string
+// BuiltInParams configures a server-hosted scorer. Kwargs are forwarded to the
+// scorer as-is, and RequiredParams overrides the example fields the scorer
+// reads; when nil, the defaults for the score type are used.
+type BuiltInParams struct {
+	Name           *string
+	Threshold      *float64
+	Model          *string
+	StrictMode     *bool
+	Kwargs         map[string]any
+	RequiredParams []string
+	// ResultType defaults to ScorerResultTypeNumeric. Binary and categorical
+	// scorers map each label in Options to a score.
+	ResultType *ScorerResultType
+	Options    map[string]float64
+	// Range declares the scale the scorer reports on. Defaults to
+	// DefaultScoreRange; when set, Threshold defaults to its midpoint.
+	Range *ScoreRange
 }
 
-type FaithfulnessScorer struct {
+// BuiltInScorer is a server-hosted scorer identified by its score type.
+type BuiltInScorer struct {
 	*apiScorer
-	requiredParams []string
 }
 
-func (f *BuiltInScorersFactory) Faithfulness(params FaithfulnessScorerParams) *FaithfulnessScorer {
+var builtInRequiredParams = map[APIScorerType][]string{
+	APIScorerTypeFaithfulness:         {"context", "actual_output"},
+	APIScorerTypeAnswerCorrectness:    {"input", "actual_output", "expected_output"},
+	APIScorerTypeAnswerRelevancy:      {"input", "actual_output"},
+	APIScorerTypeInstructionAdherence: {"input", "actual_output"},
+	APIScorerTypeToolCorrectness:      {"input", "actual_output", "tools_called", "expected_tools"},
+	APIScorerTypeHallucination:        {"input", "actual_output", "context"},
+	APIScorerTypeGroundedness:         {"input", "actual_output", "retrieval_context"},
+	APIScorerTypeContextualPrecision:  {"input", "actual_output", "expected_output", "retrieval_context"},
+	APIScorerTypeContextualRecall:     {"input", "actual_output", "expected_output", "retrieval_context"},
+	APIScorerTypeContextualRelevancy:  {"input", "actual_output", "retrieval_context"},
+	APIScorerTypeSummarization:        {"input", "actual_output"},
+	APIScorerTypeJSONCorrectness:      {"input", "actual_output"},
+	APIScorerTypeToolOrder:            {"actual_output", "expected_output"},
+	APIScorerTypeToolDependency:       {"actual_output"},
+	APIScorerTypeExecutionOrder:       {"actual_output", "expected_output"},
+	APIScorerTypeDerailment:           {"input", "actual_output"},
+}
+
+// New builds a scorer for any score type the server supports, including ones
+// this SDK has no typed helper for.
+func (f *BuiltInScorersFactory) New(scoreType APIScorerType, params BuiltInParams) (*BuiltInScorer, error) {
+	requiredParams := params.RequiredParams
+	if requiredParams == nil {
+		requiredParams = builtInRequiredParams[scoreType]
+	}
+
+	resultType := ScorerResultTypeNumeric
+	if params.ResultType != nil {
+		resultType = *params.ResultType
+	}
+	if !resultType.valid() {
+		return nil, fmt.Errorf("scorer %s has unknown result type '%s'", getString(params.Name, scoreType.String()), resultType)
+	}
+
+	scoreRange := DefaultScoreRange
+	if params.Range != nil {
+		if err := params.Range.validate(); err != nil {
+			return nil, fmt.Errorf("scorer %s has invalid score range: %w", getString(params.Name, scoreType.String()), err)
+		}
+		scoreRange = *params.Range
+	}
+
 	scorer := newAPIScorer(
-		APIScorerTypeFaithfulness,
-		getFloat(params.Threshold, 0.5),
+		scoreType,
+		getFloat(params.Threshold, scoreRange.midpoint()),
 		getString(params.Name, ""),
 		getBool(params.StrictMode, false),
 		getString(params.Model, ""),
-		[]string{"context", "actual_output"},
+		slices.Clone(requiredParams),
 	)
-
-	return &FaithfulnessScorer{
-		apiScorer:      scorer,
-		requiredParams: []string{"context", "actual_output"},
+	scorer.scoreRange = scoreRange
+	scorer.resultType = resultType
+	maps.Copy(scorer.additionalProps, params.Kwargs)
+
+	if len(params.Options) > 0 {
+		options := make(map[string]float64, len(params.Options))
+		maps.Copy(options, params.Options)
+		scorer.additionalProps["options"] = options
 	}
+
+	return &BuiltInScorer{apiScorer: scorer}, nil
 }
 
-func (s *FaithfulnessScorer) GetScorerConfig() *models.ScorerConfig {
+func (s *BuiltInScorer) GetScorerConfig() *models.ScorerConfig {
 	return s.apiScorer.toScorerConfig(s.requiredParams)
 }
 
-type AnswerCorrectnessScorerParams struct {
+func (s *BuiltInScorer) GetResultType() ScorerResultType {
+	return s.resultType
+}
+
+func (f *BuiltInScorersFactory) InstructionAdherence(params BuiltInParams) (*BuiltInScorer, error) {
+	return f.New(APIScorerTypeInstructionAdherence, params)
+}
+
+func (f *BuiltInScorersFactory) ToolCorrectness(params BuiltInParams) (*BuiltInScorer, error) {
+	return f.New(APIScorerTypeToolCorrectness, params)
+}
+
+func (f *BuiltInScorersFactory) Hallucination(params BuiltInParams) (*BuiltInScorer, error) {
+	return f.New(APIScorerTypeHallucination, params)
+}
+
+// legacy builds the fixed-scale scorers behind the typed helpers below. They
+// never set a Range or ResultType, so New cannot fail for them.
+func (f *BuiltInScorersFactory) legacy(scoreType APIScorerType, name *string, threshold *float64, model *string, strictMode *bool) *BuiltInScorer {
+	scorer, _ := f.New(scoreType, BuiltInParams{
+		Name:       name,
+		Threshold:  threshold,
+		Model:      model,
+		StrictMode: strictMode,
+	})
+	return scorer
+}
+
+type FaithfulnessScorerParams struct {
 	Threshold  *float64
 	Name       *string
 	StrictMode *bool
 	Model      *string
 }
 
-type AnswerCorrectnessScorer struct {
-	*apiScorer
-	requiredParams []string
+type FaithfulnessScorer struct {
+	*BuiltInScorer
 }
 
-func (f *BuiltInScorersFactory) AnswerCorrectness(params AnswerCorrectnessScorerParams) *AnswerCorrectnessScorer {
-	scorer := newAPIScorer(
-		APIScorerTypeAnswerCorrectness,
-		getFloat(params.Threshold, 0.5),
-		getString(params.Name, ""),
-		getBool(params.StrictMode, false),
-		getString(params.Model, ""),
-		[]string{"input", "actual_output", "expected_output"},
-	)
+func (f *BuiltInScorersFactory) Faithfulness(params FaithfulnessScorerParams) *FaithfulnessScorer {
+	return &FaithfulnessScorer{BuiltInScorer: f.legacy(APIScorerTypeFaithfulness, params.Name, params.Threshold, params.Model, params.StrictMode)}
+}
 
-	return &AnswerCorrectnessScorer{
-		apiScorer:      scorer,
-		requiredParams: []string{"input", "actual_output", "expected_output"},
-	}
+type AnswerCorrectnessScorerParams struct {
+	Threshold  *float64
+	Name       *string
+	StrictMode *bool
+	Model      *string
 }
 
-func (s *AnswerCorrectnessScorer) GetScorerConfig() *models.ScorerConfig {
-	return s.apiScorer.toScorerConfig(s.requiredParams)
+type AnswerCorrectnessScorer struct {
+	*BuiltInScorer
+}
+
+func (f *BuiltInScorersFactory) AnswerCorrectness(params AnswerCorrectnessScorerParams) *AnswerCorrectnessScorer {
+	return &AnswerCorrectnessScorer{BuiltInScorer: f.legacy(APIScorerTypeAnswerCorrectness, params.Name, params.Threshold, params.Model, params.StrictMode)}
 }
 
 type AnswerRelevancyScorerParams struct {
@@ -76,26 +168,9 @@ type AnswerRelevancyScorerParams struct {
 }
 
 type AnswerRelevancyScorer struct {
-	*apiScorer
-	requiredParams []string
+	*BuiltInScorer
 }
 
 func (f *BuiltInScorersFactory) AnswerRelevancy(params AnswerRelevancyScorerParams) *AnswerRelevancyScorer {
-	scorer := newAPIScorer(
-		APIScorerTypeAnswerRelevancy,
-		getFloat(params.Threshold, 0.5),
-		getString(params.Name, ""),
-		getBool(params.StrictMode, false),
-		getString(params.Model, ""),
-		[]string{"input", "actual_output"},
-	)
-
-	return &AnswerRelevancyScorer{
-		apiScorer:      scorer,
-		requiredParams: []string{"input", "actual_output"},
-	}
-}
-
-func (s *AnswerRelevancyScorer) GetScorerConfig() *models.ScorerConfig {
-	return s.apiScorer.toScorerConfig(s.requiredParams)
+	return &AnswerRelevancyScorer{BuiltInScorer: f.legacy(APIScorerTypeAnswerRelevancy, params.Name, params.Threshold, params.Model, params.StrictMode)}
 }
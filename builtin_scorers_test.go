@@ -0,0 +1,23 @@
+package judgeval
+
+import "testing"
+
+func TestBuiltInNewRejectsInvalidParams(t *testing.T) {
+	f := &BuiltInScorersFactory{}
+
+	if _, err := f.New(APIScorerTypeFaithfulness, BuiltInParams{Range: &ScoreRange{Min: 5, Max: 1}}); err == nil {
+		t.Error("expected an error for an inverted score range")
+	}
+	unknown := ScorerResultType("ranking")
+	if _, err := f.New(APIScorerTypeFaithfulness, BuiltInParams{ResultType: &unknown}); err == nil {
+		t.Error("expected an error for an unknown result type")
+	}
+
+	scorer, err := f.New(APIScorerTypeFaithfulness, BuiltInParams{Range: &ScoreRange{Min: 1, Max: 5}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if got := scorer.threshold; got != 3 {
+		t.Errorf("threshold = %v, want the range midpoint 3", got)
+	}
+}
@@ -0,0 +1,262 @@
+package judgeval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+)
+
+type CompositeMode string
+
+const (
+	// CompositeModeAllOf passes when every child scorer passes.
+	CompositeModeAllOf CompositeMode = "all_of"
+	// CompositeModeAnyOf passes when at least one child scorer passes.
+	CompositeModeAnyOf CompositeMode = "any_of"
+	// CompositeModeWeightedMean passes when the weighted mean of the child
+	// scores, normalized by each child's ScoreRange, reaches the composite's
+	// threshold.
+	CompositeModeWeightedMean CompositeMode = "weighted_mean"
+)
+
+type CompositeScorerParams struct {
+	Name    string
+	Mode    CompositeMode
+	Scorers []BaseScorer
+	// Weights are matched to Scorers by position and only used by
+	// CompositeModeWeightedMean. Defaults to equal weights.
+	Weights []float64
+	// Threshold applies to CompositeModeWeightedMean. Defaults to 0.5.
+	Threshold *float64
+	// RemoteTimeout bounds how long server-hosted children are awaited;
+	// children still unscored by then get an error result. Defaults to 5
+	// minutes.
+	RemoteTimeout *time.Duration
+}
+
+// CompositeScorer combines several scorers into a single verdict. Children
+// may be local scorers, other composites, or server-hosted scorers; the
+// latter are evaluated on the platform and awaited before combining.
+type CompositeScorer struct {
+	name      string
+	mode      CompositeMode
+	scorers   []BaseScorer
+	weights   []float64
+	threshold float64
+	remote    *remoteScorerRunner
+}
+
+var _ LocalScorer = (*CompositeScorer)(nil)
+
+func (f *ScorersFactory) Composite(params CompositeScorerParams) (*CompositeScorer, error) {
+	if params.Name == "" {
+		return nil, errors.New("composite scorer name is required")
+	}
+	if len(params.Scorers) == 0 {
+		return nil, fmt.Errorf("composite scorer '%s' requires at least one child scorer", params.Name)
+	}
+
+	switch params.Mode {
+	case CompositeModeAllOf, CompositeModeAnyOf, CompositeModeWeightedMean:
+	default:
+		return nil, fmt.Errorf("composite scorer '%s' has unknown mode '%s'", params.Name, params.Mode)
+	}
+
+	seen := make(map[string]bool, len(params.Scorers))
+	for _, scorer := range params.Scorers {
+		if seen[scorer.GetName()] {
+			return nil, fmt.Errorf("composite scorer '%s' has duplicate child scorer name '%s'", params.Name, scorer.GetName())
+		}
+		seen[scorer.GetName()] = true
+	}
+
+	weights := params.Weights
+	if weights == nil {
+		weights = make([]float64, len(params.Scorers))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	if len(weights) != len(params.Scorers) {
+		return nil, fmt.Errorf("composite scorer '%s' has %d weights for %d scorers", params.Name, len(weights), len(params.Scorers))
+	}
+
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("composite scorer '%s' has negative weight %v", params.Name, w)
+		}
+		total += w
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("composite scorer '%s' weights sum to zero", params.Name)
+	}
+
+	return &CompositeScorer{
+		name:      params.Name,
+		mode:      params.Mode,
+		scorers:   append([]BaseScorer(nil), params.Scorers...),
+		weights:   append([]float64(nil), weights...),
+		threshold: getFloat(params.Threshold, 0.5),
+		remote: &remoteScorerRunner{
+			client:    f.client,
+			projectID: f.projectID,
+			timeout:   getDuration(params.RemoteTimeout, defaultRemoteScorerTimeout),
+		},
+	}, nil
+}
+
+func (s *CompositeScorer) GetName() string {
+	return s.name
+}
+
+func (s *CompositeScorer) GetMode() CompositeMode {
+	return s.mode
+}
+
+func (s *CompositeScorer) GetThreshold() float64 {
+	return s.threshold
+}
+
+func (s *CompositeScorer) GetScorerConfig() *models.ScorerConfig {
+	children := make([]string, len(s.scorers))
+	for i, scorer := range s.scorers {
+		children[i] = scorer.GetName()
+	}
+
+	return &models.ScorerConfig{
+		ScoreType: "Composite",
+		Name:      s.name,
+		Threshold: s.threshold,
+		Kwargs: map[string]any{
+			"mode":    string(s.mode),
+			"scorers": children,
+			"weights": s.weights,
+		},
+		ResultType: "numeric",
+	}
+}
+
+// Score runs every child against the example and combines their results.
+// Each child's result is kept in the breakdown under its name. A child that
+// errors counts as failed for all_of and any_of and is left out of the
+// weighted mean; either way it is listed in the reason.
+func (s *CompositeScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	childResults, err := s.scoreChildren(ctx, example)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := make(map[string]any, len(childResults))
+	passed := 0
+	weightedSum, totalWeight := 0.0, 0.0
+	var failures, errored []string
+
+	for i, child := range childResults {
+		entry := map[string]any{
+			"score":            child.Score,
+			"normalized_score": child.NormalizedScore,
+			"success":          child.Success,
+		}
+		if child.Reason != "" {
+			entry["reason"] = child.Reason
+		}
+		if child.Error != "" {
+			entry["error"] = child.Error
+		}
+		if len(child.Breakdown) > 0 {
+			entry["breakdown"] = child.Breakdown
+		}
+		breakdown[child.Name] = entry
+
+		if child.Error != "" {
+			errored = append(errored, fmt.Sprintf("%s (%s)", child.Name, child.Error))
+		} else if child.Success {
+			passed++
+		} else {
+			failures = append(failures, child.Name)
+		}
+		if child.Error == "" {
+			weightedSum += s.weights[i] * child.NormalizedScore
+			totalWeight += s.weights[i]
+		}
+	}
+
+	result := &ScorerResult{
+		Name:      s.name,
+		Threshold: s.threshold,
+		Breakdown: breakdown,
+	}
+
+	switch s.mode {
+	case CompositeModeAllOf:
+		result.Score = float64(passed) / float64(len(childResults))
+		result.Success = passed == len(childResults)
+	case CompositeModeAnyOf:
+		result.Score = float64(passed) / float64(len(childResults))
+		result.Success = passed > 0
+	case CompositeModeWeightedMean:
+		if totalWeight == 0 {
+			result.Error = "every child scorer errored: " + strings.Join(errored, ", ")
+			return result, nil
+		}
+		result.Score = weightedSum / totalWeight
+		result.Success = DefaultScoreRange.Passes(result.Score, s.threshold)
+	}
+
+	var reasons []string
+	if !result.Success && len(failures) > 0 {
+		reasons = append(reasons, "failed: "+strings.Join(failures, ", "))
+	}
+	if len(errored) > 0 {
+		reasons = append(reasons, "errored: "+strings.Join(errored, ", "))
+	}
+	result.Reason = strings.Join(reasons, "; ")
+	return result, nil
+}
+
+func (s *CompositeScorer) scoreChildren(ctx context.Context, example *Example) ([]*ScorerResult, error) {
+	results := make([]*ScorerResult, len(s.scorers))
+
+	var remoteScorers []BaseScorer
+	for i, scorer := range s.scorers {
+		local, ok := scorer.(LocalScorer)
+		if !ok {
+			remoteScorers = append(remoteScorers, scorer)
+			continue
+		}
+
+		result, err := local.Score(ctx, example)
+		if err != nil {
+			result = &ScorerResult{Name: scorer.GetName(), Error: err.Error()}
+		} else {
+			result.NormalizedScore = scoreRangeOf(scorer).Normalize(result.Score)
+		}
+		results[i] = result
+	}
+
+	if len(remoteScorers) == 0 {
+		return results, nil
+	}
+
+	remoteResults, err := s.remote.score(ctx, example, remoteScorers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run server-hosted scorers for composite scorer '%s': %w", s.name, err)
+	}
+
+	for i, scorer := range s.scorers {
+		if results[i] != nil {
+			continue
+		}
+		result, ok := remoteResults[scorer.GetName()]
+		if !ok {
+			result = &ScorerResult{Name: scorer.GetName(), Error: "no result returned by the server"}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
@@ -0,0 +1,252 @@
+package judgeval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api"
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+)
+
+// stubScorer is a local scorer whose results come from score.
+type stubScorer struct {
+	name  string
+	score func(example *Example) (*ScorerResult, error)
+}
+
+func (s *stubScorer) GetName() string {
+	return s.name
+}
+
+func (s *stubScorer) GetScorerConfig() *models.ScorerConfig {
+	return &models.ScorerConfig{ScoreType: "Stub", Name: s.name}
+}
+
+func (s *stubScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	return s.score(example)
+}
+
+func fixedScorer(name string, score float64) *stubScorer {
+	return &stubScorer{name: name, score: func(*Example) (*ScorerResult, error) {
+		return &ScorerResult{Name: name, Score: score, Threshold: 0.5, Success: score >= 0.5}, nil
+	}}
+}
+
+func erroringScorer(name string) *stubScorer {
+	return &stubScorer{name: name, score: func(*Example) (*ScorerResult, error) {
+		return nil, errors.New("boom")
+	}}
+}
+
+func TestCompositeScorerModes(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        CompositeMode
+		scorers     []BaseScorer
+		weights     []float64
+		wantScore   float64
+		wantSuccess bool
+		wantError   bool
+		wantReason  string
+	}{
+		{
+			name:        "all_of passes",
+			mode:        CompositeModeAllOf,
+			scorers:     []BaseScorer{fixedScorer("a", 1), fixedScorer("b", 0.8)},
+			wantScore:   1,
+			wantSuccess: true,
+		},
+		{
+			name:       "all_of fails",
+			mode:       CompositeModeAllOf,
+			scorers:    []BaseScorer{fixedScorer("a", 1), fixedScorer("b", 0.2)},
+			wantScore:  0.5,
+			wantReason: "failed: b",
+		},
+		{
+			name:       "all_of fails on an errored child",
+			mode:       CompositeModeAllOf,
+			scorers:    []BaseScorer{fixedScorer("a", 1), erroringScorer("b")},
+			wantScore:  0.5,
+			wantReason: "errored: b (boom)",
+		},
+		{
+			name:        "any_of passes",
+			mode:        CompositeModeAnyOf,
+			scorers:     []BaseScorer{fixedScorer("a", 0), fixedScorer("b", 0.9)},
+			wantScore:   0.5,
+			wantSuccess: true,
+		},
+		{
+			name:       "any_of fails",
+			mode:       CompositeModeAnyOf,
+			scorers:    []BaseScorer{fixedScorer("a", 0), erroringScorer("b")},
+			wantScore:  0,
+			wantReason: "failed: a; errored: b (boom)",
+		},
+		{
+			name:        "weighted_mean",
+			mode:        CompositeModeWeightedMean,
+			scorers:     []BaseScorer{fixedScorer("a", 1), fixedScorer("b", 0)},
+			weights:     []float64{3, 1},
+			wantScore:   0.75,
+			wantSuccess: true,
+		},
+		{
+			name:        "weighted_mean leaves errored children out",
+			mode:        CompositeModeWeightedMean,
+			scorers:     []BaseScorer{fixedScorer("a", 0.8), erroringScorer("b")},
+			wantScore:   0.8,
+			wantSuccess: true,
+			wantReason:  "errored: b (boom)",
+		},
+		{
+			name:      "weighted_mean with every child errored",
+			mode:      CompositeModeWeightedMean,
+			scorers:   []BaseScorer{erroringScorer("a"), erroringScorer("b")},
+			wantError: true,
+		},
+	}
+
+	f := &ScorersFactory{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer, err := f.Composite(CompositeScorerParams{Name: "composite", Mode: tt.mode, Scorers: tt.scorers, Weights: tt.weights})
+			if err != nil {
+				t.Fatalf("Composite: %v", err)
+			}
+			result, err := scorer.Score(context.Background(), NewExample(ExampleParams{}))
+			if err != nil {
+				t.Fatalf("Score: %v", err)
+			}
+			if tt.wantError {
+				if result.Error == "" || result.Success {
+					t.Errorf("result = %+v, want an error result", result)
+				}
+				return
+			}
+			if math.Abs(result.Score-tt.wantScore) > metricTolerance {
+				t.Errorf("score = %v, want %v", result.Score, tt.wantScore)
+			}
+			if result.Success != tt.wantSuccess {
+				t.Errorf("success = %v, want %v", result.Success, tt.wantSuccess)
+			}
+			if result.Reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", result.Reason, tt.wantReason)
+			}
+			if len(result.Breakdown) != len(tt.scorers) {
+				t.Errorf("breakdown has %d entries, want %d", len(result.Breakdown), len(tt.scorers))
+			}
+		})
+	}
+}
+
+// newTestRemoteScorerRunner serves the evaluation endpoints; experiment
+// returns the scorers reported for the submitted example on the given poll.
+func newTestRemoteScorerRunner(t *testing.T, timeout time.Duration, experiment func(poll int) []models.ExperimentScorer) (*remoteScorerRunner, *atomic.Int32) {
+	t.Helper()
+	var (
+		mu        sync.Mutex
+		exampleID string
+		polls     atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/projects/project/evaluate/examples":
+			var run models.ExampleEvaluationRun
+			if err := json.NewDecoder(r.Body).Decode(&run); err != nil {
+				t.Errorf("decode run: %v", err)
+			}
+			mu.Lock()
+			exampleID = run.Examples[0].ExampleId
+			mu.Unlock()
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/projects/project/experiments/"):
+			poll := int(polls.Add(1))
+			mu.Lock()
+			item := models.ExperimentRunItem{ExampleId: exampleID, Scorers: experiment(poll)}
+			mu.Unlock()
+			json.NewEncoder(w).Encode(models.FetchExperimentRunResponse{Results: []models.ExperimentRunItem{item}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return &remoteScorerRunner{
+		client:       api.NewClient(server.URL, "key", "org"),
+		projectID:    "project",
+		timeout:      timeout,
+		pollInterval: time.Millisecond,
+	}, &polls
+}
+
+func TestRemoteScorerRunnerPolls(t *testing.T) {
+	runner, polls := newTestRemoteScorerRunner(t, time.Minute, func(poll int) []models.ExperimentScorer {
+		scorers := []models.ExperimentScorer{{Name: "Faithfulness", Score: 0.9, Threshold: 0.5}}
+		if poll >= 3 {
+			scorers = append(scorers, models.ExperimentScorer{Name: "Hallucination", Score: 0.1, Threshold: 0.5})
+		}
+		return scorers
+	})
+
+	f := &BuiltInScorersFactory{}
+	faithfulness, _ := f.New(APIScorerTypeFaithfulness, BuiltInParams{})
+	hallucination, _ := f.New(APIScorerTypeHallucination, BuiltInParams{})
+
+	results, err := runner.score(context.Background(), NewExample(ExampleParams{}), []BaseScorer{faithfulness, hallucination})
+	if err != nil {
+		t.Fatalf("score: %v", err)
+	}
+	if got := polls.Load(); got != 3 {
+		t.Errorf("polled %d times, want 3", got)
+	}
+	if r := results["Faithfulness"]; r == nil || !r.Success || r.Score != 0.9 {
+		t.Errorf("Faithfulness result = %+v", r)
+	}
+	if r := results["Hallucination"]; r == nil || r.Success || r.Error != "" {
+		t.Errorf("Hallucination result = %+v", r)
+	}
+}
+
+func TestRemoteScorerRunnerTimeout(t *testing.T) {
+	runner, _ := newTestRemoteScorerRunner(t, 20*time.Millisecond, func(int) []models.ExperimentScorer {
+		return []models.ExperimentScorer{{Name: "Faithfulness", Score: 0.9, Threshold: 0.5}}
+	})
+
+	f := &BuiltInScorersFactory{}
+	faithfulness, _ := f.New(APIScorerTypeFaithfulness, BuiltInParams{})
+	hallucination, _ := f.New(APIScorerTypeHallucination, BuiltInParams{})
+
+	results, err := runner.score(context.Background(), NewExample(ExampleParams{}), []BaseScorer{faithfulness, hallucination})
+	if err != nil {
+		t.Fatalf("score: %v", err)
+	}
+	if r := results["Faithfulness"]; r == nil || r.Error != "" || !r.Success {
+		t.Errorf("Faithfulness result = %+v, want the partial result", r)
+	}
+	if r := results["Hallucination"]; r == nil || !strings.Contains(r.Error, "timed out") {
+		t.Errorf("Hallucination result = %+v, want a timeout error", r)
+	}
+}
+
+func TestRemoteScorerRunnerCanceled(t *testing.T) {
+	runner, _ := newTestRemoteScorerRunner(t, time.Minute, func(int) []models.ExperimentScorer { return nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	faithfulness, _ := (&BuiltInScorersFactory{}).New(APIScorerTypeFaithfulness, BuiltInParams{})
+	if _, err := runner.score(ctx, NewExample(ExampleParams{}), []BaseScorer{faithfulness}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("score error = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -1,30 +1,248 @@
 package judgeval
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api"
 	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+	"github.com/JudgmentLabs/judgeval-go/logger"
 )
 
-type CustomScorerFactory struct{}
+const customScorerLookupTTL = 5 * time.Minute
+
+type CustomScorerFactory struct {
+	client      *api.Client
+	projectName string
+	projectID   string
+	lookupCache sync.Map
+}
+
+// customScorerLookup records that a scorer exists until expiresAt, with the
+// metadata known for it.
+type customScorerLookup struct {
+	metadata  customScorerMetadata
+	expiresAt time.Time
+}
+
+// customScorerMetadata is only known for scorers uploaded through this
+// factory: the API's exists route reports nothing but existence.
+type customScorerMetadata struct {
+	scorerType   string
+	responseType string
+	version      int
+}
 
 type CustomScorerParams struct {
 	Name      string
 	ClassName *string
 }
 
+// CustomScorerUploadParams describes a custom scorer to publish. ScorerPath and
+// RequirementsPath are read from FS when it is set, and from the local
+// filesystem otherwise.
+type CustomScorerUploadParams struct {
+	Name             string
+	ScorerPath       string
+	RequirementsPath *string
+	FS               fs.FS
+	ClassName        *string
+	ScorerType       *string
+	ResponseType     *string
+	Overwrite        *bool
+	Version          *int
+}
+
 type CustomScorer struct {
 	name         string
 	className    string
 	serverHosted bool
+	metadata     customScorerMetadata
+	scoreRange   ScoreRange
 }
 
-func (f *CustomScorerFactory) Get(name string, className string) (*CustomScorer, error) {
+var customScorerClassPattern = regexp.MustCompile(`(?m)^class\s+(\w+)\s*\(\s*(?:\w+\.)*(ExampleScorer|TraceScorer)\b`)
+
+// Get verifies that the named custom scorer has been uploaded to the project
+// and returns it. A missing scorer yields a *ScorerNotFoundError.
+func (f *CustomScorerFactory) Get(ctx context.Context, name string, className string) (*CustomScorer, error) {
+	if name == "" {
+		return nil, errors.New("custom scorer name is required")
+	}
+
+	metadata, err := f.lookup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
 	return &CustomScorer{
 		name:         name,
 		className:    className,
 		serverHosted: true,
+		metadata:     metadata,
+		scoreRange:   DefaultScoreRange,
+	}, nil
+}
+
+func (f *CustomScorerFactory) lookup(ctx context.Context, name string) (customScorerMetadata, error) {
+	cacheKey := f.buildCacheKey(name)
+	if cached, ok := f.lookupCache.Load(cacheKey); ok {
+		entry := cached.(*customScorerLookup)
+		if time.Now().Before(entry.expiresAt) {
+			return entry.metadata, nil
+		}
+		f.lookupCache.CompareAndDelete(cacheKey, cached)
+	}
+
+	resp, err := withContext(ctx, func() (*models.CustomScorerExistsResponse, error) {
+		return f.client.GetProjectsScorersCustomByNameExists(f.projectID, name)
+	})
+	if err != nil {
+		return customScorerMetadata{}, fmt.Errorf("failed to check if custom scorer '%s' exists: %w", name, err)
+	}
+	if !resp.Exists {
+		return customScorerMetadata{}, &ScorerNotFoundError{Name: name, ScoreType: APIScorerTypeCustom}
+	}
+
+	f.remember(name, customScorerMetadata{})
+	return customScorerMetadata{}, nil
+}
+
+func (f *CustomScorerFactory) remember(name string, metadata customScorerMetadata) {
+	f.lookupCache.Store(f.buildCacheKey(name), &customScorerLookup{
+		metadata:  metadata,
+		expiresAt: time.Now().Add(customScorerLookupTTL),
+	})
+}
+
+func (f *CustomScorerFactory) buildCacheKey(name string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", f.projectID, name, f.client.GetAPIKey(), f.client.GetOrganizationID())
+}
+
+// Upload publishes the scorer source and requirements to the platform and
+// returns a CustomScorer bound to the uploaded name. When ClassName or
+// ScorerType are not given they are inferred from the first class in the
+// source that extends ExampleScorer or TraceScorer.
+func (f *CustomScorerFactory) Upload(ctx context.Context, params CustomScorerUploadParams) (*CustomScorer, error) {
+	if params.Name == "" {
+		return nil, errors.New("custom scorer name is required")
+	}
+	if params.ScorerPath == "" {
+		return nil, fmt.Errorf("scorer path is required for custom scorer '%s'", params.Name)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	code, err := readScorerFile(params.FS, params.ScorerPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scorer file for custom scorer '%s': %w", params.Name, err)
+	}
+
+	requirements := ""
+	if params.RequirementsPath != nil {
+		data, err := readScorerFile(params.FS, *params.RequirementsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read requirements file for custom scorer '%s': %w", params.Name, err)
+		}
+		requirements = string(data)
+	}
+
+	className := getString(params.ClassName, "")
+	scorerType := getString(params.ScorerType, "")
+	if className == "" || scorerType == "" {
+		match := customScorerClassPattern.FindStringSubmatch(string(code))
+		if match == nil {
+			return nil, fmt.Errorf("no class extending ExampleScorer or TraceScorer found in %s", params.ScorerPath)
+		}
+		if className == "" {
+			className = match[1]
+		}
+		if scorerType == "" {
+			scorerType = "example"
+			if match[2] == "TraceScorer" {
+				scorerType = "trace"
+			}
+		}
+	}
+
+	payload := &models.UploadCustomScorerRequest{
+		ScorerName:       params.Name,
+		ScorerCode:       string(code),
+		RequirementsText: requirements,
+		ClassName:        className,
+		Overwrite:        getBool(params.Overwrite, false),
+		ScorerType:       scorerType,
+		ResponseType:     getString(params.ResponseType, "numeric"),
+	}
+	if params.Version != nil {
+		payload.Version = float64(*params.Version)
+	}
+
+	resp, err := withContext(ctx, func() (*models.UploadCustomScorerResponse, error) {
+		return f.client.PostProjectsScorersCustom(f.projectID, payload)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload custom scorer '%s': %w", params.Name, err)
+	}
+
+	logger.Info("Uploaded custom scorer %s: %s", params.Name, resp.Message)
+
+	metadata := customScorerMetadata{
+		scorerType:   payload.ScorerType,
+		responseType: payload.ResponseType,
+		version:      getInt(params.Version, 0),
+	}
+	f.remember(params.Name, metadata)
+
+	return &CustomScorer{
+		name:         params.Name,
+		className:    className,
+		serverHosted: true,
+		metadata:     metadata,
+		scoreRange:   DefaultScoreRange,
 	}, nil
 }
 
+// withContext runs call, which the generated client cannot cancel, and stops
+// waiting for it once ctx is done.
+func withContext[T any](ctx context.Context, call func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := call()
+		done <- result{value, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+func readScorerFile(fsys fs.FS, path string) ([]byte, error) {
+	if fsys != nil {
+		return fs.ReadFile(fsys, path)
+	}
+	return os.ReadFile(path)
+}
+
 func (s *CustomScorer) GetName() string {
 	return s.name
 }
@@ -37,20 +255,55 @@ func (s *CustomScorer) IsServerHosted() bool {
 	return s.serverHosted
 }
 
+// GetScorerType returns "example" or "trace". Like GetResponseType and
+// GetVersion, it is only known for scorers uploaded through this factory
+// and is empty otherwise, because the platform does not report it.
+func (s *CustomScorer) GetScorerType() string {
+	return s.metadata.scorerType
+}
+
+func (s *CustomScorer) GetResponseType() string {
+	return s.metadata.responseType
+}
+
+// GetVersion returns the uploaded version, or 0 when unknown.
+func (s *CustomScorer) GetVersion() int {
+	return s.metadata.version
+}
+
+func (s *CustomScorer) GetScoreRange() ScoreRange {
+	return s.scoreRange
+}
+
+// SetScoreRange declares the scale the scorer's code reports on, so its
+// results are judged and normalized against it.
+func (s *CustomScorer) SetScoreRange(scoreRange ScoreRange) error {
+	if err := scoreRange.validate(); err != nil {
+		return err
+	}
+	s.scoreRange = scoreRange
+	return nil
+}
+
 func (s *CustomScorer) GetScorerConfig() *models.ScorerConfig {
+	kwargs := map[string]interface{}{
+		"class_name":    s.className,
+		"server_hosted": s.serverHosted,
+	}
+	s.scoreRange.toKwargs(kwargs)
+
 	return &models.ScorerConfig{
 		ScoreType: APIScorerTypeCustom.String(),
 		Name:      s.name,
-		Kwargs: map[string]interface{}{
-			"class_name":    s.className,
-			"server_hosted": s.serverHosted,
-		},
+		Kwargs:    kwargs,
 	}
 }
 
 func (s *CustomScorer) GetBaseScorer() models.BaseScorer {
 	return models.BaseScorer{
-		ScoreType: APIScorerTypeCustom.String(),
-		Name:      s.name,
+		ScoreType:         APIScorerTypeCustom.String(),
+		Name:              s.name,
+		MinimumScoreRange: s.scoreRange.Min,
+		MaximumScoreRange: s.scoreRange.Max,
 	}
 }
@@ -0,0 +1,98 @@
+package judgeval
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api"
+)
+
+func newTestCustomScorerFactory(t *testing.T, handler http.HandlerFunc) *CustomScorerFactory {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &CustomScorerFactory{client: api.NewClient(server.URL, "key", "org"), projectID: "project"}
+}
+
+func TestCustomScorerFactoryGet(t *testing.T) {
+	var calls atomic.Int32
+	factory := newTestCustomScorerFactory(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Path {
+		case "/v1/projects/project/scorers/custom/present/exists":
+			w.Write([]byte(`{"exists":true}`))
+		default:
+			w.Write([]byte(`{"exists":false}`))
+		}
+	})
+	ctx := context.Background()
+
+	scorer, err := factory.Get(ctx, "present", "Present")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if scorer.GetName() != "present" || scorer.GetClassName() != "Present" || scorer.GetVersion() != 0 {
+		t.Errorf("Get() = %+v", scorer)
+	}
+	if _, err := factory.Get(ctx, "present", "Present"); err != nil {
+		t.Fatalf("cached Get() error = %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("server called %d times, want 1 thanks to the lookup cache", n)
+	}
+
+	if _, err := factory.Get(ctx, "missing", "Missing"); !errors.Is(err, ErrScorerNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrScorerNotFound", err)
+	}
+}
+
+func TestCustomScorerFactoryGetCanceled(t *testing.T) {
+	factory := newTestCustomScorerFactory(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected for a canceled context")
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := factory.Get(ctx, "present", "Present"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Get() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCustomScorerFactoryUploadMetadata(t *testing.T) {
+	var calls atomic.Int32
+	factory := newTestCustomScorerFactory(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"message":"ok"}`))
+	})
+	fsys := fstest.MapFS{"scorer.py": {Data: []byte("class Helpful(TraceScorer):\n    pass\n")}}
+
+	scorer, err := factory.Upload(context.Background(), CustomScorerUploadParams{
+		Name:         "helpful",
+		ScorerPath:   "scorer.py",
+		FS:           fsys,
+		ResponseType: String("binary"),
+		Version:      Int(3),
+	})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if scorer.GetClassName() != "Helpful" || scorer.GetScorerType() != "trace" || scorer.GetResponseType() != "binary" || scorer.GetVersion() != 3 {
+		t.Errorf("Upload() = %+v", scorer)
+	}
+
+	got, err := factory.Get(context.Background(), "helpful", "Helpful")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.GetScorerType() != "trace" || got.GetResponseType() != "binary" || got.GetVersion() != 3 {
+		t.Errorf("Get() after Upload = %+v, want the uploaded metadata", got)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("server called %d times, want only the upload", n)
+	}
+}
@@ -1,6 +1,16 @@
 package judgeval
 
-import "github.com/JudgmentLabs/judgeval-go/internal/api"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api"
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+	"github.com/JudgmentLabs/judgeval-go/logger"
+	"github.com/google/uuid"
+)
 
 type EvaluationFactory struct {
 	client      *api.Client
@@ -24,3 +34,281 @@ func (f *EvaluationFactory) Create(params EvaluationCreateParams) *Evaluation {
 		projectID:   f.projectID,
 	}
 }
+
+const (
+	remoteScorerPollInterval   = time.Second
+	defaultRemoteScorerTimeout = 5 * time.Minute
+)
+
+// remoteScorerRunner scores a single example with server-hosted scorers and
+// waits for the results, so they can be combined with local scorers.
+type remoteScorerRunner struct {
+	client       *api.Client
+	projectID    string
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+// score submits the example and polls until every scorer has a result. After
+// the runner's timeout it stops waiting and returns what the server reported
+// so far, with an error result for each scorer still missing.
+func (r *remoteScorerRunner) score(ctx context.Context, example *Example, scorers []BaseScorer) (map[string]*ScorerResult, error) {
+	if r == nil || r.client == nil {
+		return nil, errors.New("server-hosted scorers require a client; create the scorer through the Scorers factory")
+	}
+
+	runID := uuid.New().String()
+	run := &models.ExampleEvaluationRun{
+		Id:              runID,
+		ProjectId:       r.projectID,
+		EvalName:        "composite_" + runID,
+		Examples:        []models.Example{example.toModel()},
+		JudgmentScorers: []models.ScorerConfig{},
+		CustomScorers:   []models.BaseScorer{},
+		CreatedAt:       time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, scorer := range scorers {
+		if cs, ok := scorer.(*CustomScorer); ok {
+			run.CustomScorers = append(run.CustomScorers, cs.GetBaseScorer())
+		} else {
+			run.JudgmentScorers = append(run.JudgmentScorers, *scorer.GetScorerConfig())
+		}
+	}
+
+	if _, err := r.client.PostProjectsEvaluateExamples(r.projectID, run); err != nil {
+		return nil, fmt.Errorf("failed to submit evaluation run: %w", err)
+	}
+
+	timeout := r.timeout
+	if timeout <= 0 {
+		timeout = defaultRemoteScorerTimeout
+	}
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	pollInterval := r.pollInterval
+	if pollInterval <= 0 {
+		pollInterval = remoteScorerPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	results := map[string]*ScorerResult{}
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w: last poll error: %v", ctx.Err(), lastErr)
+			}
+			return nil, ctx.Err()
+		case <-deadline.C:
+			message := fmt.Sprintf("timed out after %s waiting for the server to score", timeout)
+			if lastErr != nil {
+				message += fmt.Sprintf(" (last poll error: %v)", lastErr)
+			}
+			logger.Warning("Evaluation run %s: %s", runID, message)
+			for _, scorer := range scorers {
+				if _, ok := results[scorer.GetName()]; !ok {
+					results[scorer.GetName()] = &ScorerResult{Name: scorer.GetName(), Error: message}
+				}
+			}
+			return results, nil
+		case <-ticker.C:
+		}
+
+		resp, err := r.client.GetProjectsExperimentsByRunId(r.projectID, runID)
+		if err != nil {
+			lastErr = err
+			logger.Debug("Polling evaluation run %s: %v", runID, err)
+			continue
+		}
+
+		for _, item := range resp.Results {
+			if item.ExampleId != example.exampleID {
+				continue
+			}
+			results = experimentScorerResults(item.Scorers, scorers)
+			if len(item.Scorers) >= len(scorers) {
+				return results, nil
+			}
+		}
+	}
+}
+
+func experimentScorerResults(experimentScorers []models.ExperimentScorer, scorers []BaseScorer) map[string]*ScorerResult {
+	results := make(map[string]*ScorerResult, len(experimentScorers))
+	for _, s := range experimentScorers {
+		results[s.Name] = &ScorerResult{
+			Name:      s.Name,
+			Score:     s.Score,
+			Threshold: s.Threshold,
+			Reason:    s.Reason,
+			Label:     experimentScorerLabel(s),
+			Breakdown: s.AdditionalMetadata,
+			Error:     s.Error,
+		}
+	}
+	// Pass/fail is recomputed locally so that declared score ranges are
+	// honored the same way as for local scorers.
+	for _, scorer := range scorers {
+		if result, ok := results[scorer.GetName()]; ok && result.Error == "" {
+			scoreRange := scoreRangeOf(scorer)
+			result.Success = scoreRange.Passes(result.Score, result.Threshold)
+			result.NormalizedScore = scoreRange.Normalize(result.Score)
+		}
+	}
+	return results
+}
+
+func experimentScorerLabel(s models.ExperimentScorer) string {
+	if label, ok := s.AdditionalProperties["label"].(string); ok {
+		return label
+	}
+	for _, key := range []string{"label", "choice"} {
+		if label, ok := s.AdditionalMetadata[key].(string); ok {
+			return label
+		}
+	}
+	return ""
+}
+
+type EvaluationRunParams struct {
+	EvalName string
+	Examples []*Example
+	Scorers  []LocalScorer
+	// Report uploads the results to the project with PostProjectsEvalResults.
+	// Defaults to true.
+	Report *bool
+}
+
+// ExampleResult holds every scorer's result for one example.
+type ExampleResult struct {
+	Example  *Example
+	Results  []*ScorerResult
+	Duration time.Duration
+}
+
+// Success reports whether every scorer passed.
+func (r *ExampleResult) Success() bool {
+	for _, result := range r.Results {
+		if !result.Success {
+			return false
+		}
+	}
+	return true
+}
+
+type EvaluationResult struct {
+	EvalName     string
+	Results      []*ExampleResult
+	UIResultsURL string
+}
+
+// Run scores each example with every local scorer in-process. A scorer that
+// returns an error is recorded as a failed result carrying the error message
+// rather than aborting the run.
+func (e *Evaluation) Run(ctx context.Context, params EvaluationRunParams) (*EvaluationResult, error) {
+	if params.EvalName == "" {
+		return nil, errors.New("evaluation name is required")
+	}
+	if len(params.Scorers) == 0 {
+		return nil, errors.New("at least one scorer is required")
+	}
+
+	result := &EvaluationResult{
+		EvalName: params.EvalName,
+		Results:  make([]*ExampleResult, 0, len(params.Examples)),
+	}
+
+	for _, example := range params.Examples {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result.Results = append(result.Results, scoreExample(ctx, example, params.Scorers))
+	}
+
+	if getBool(params.Report, true) {
+		url, err := e.report(params.EvalName, params.Scorers, result.Results)
+		if err != nil {
+			return result, err
+		}
+		result.UIResultsURL = url
+	}
+
+	return result, nil
+}
+
+func scoreExample(ctx context.Context, example *Example, scorers []LocalScorer) *ExampleResult {
+	start := time.Now()
+	exampleResult := &ExampleResult{
+		Example: example,
+		Results: make([]*ScorerResult, 0, len(scorers)),
+	}
+
+	for _, scorer := range scorers {
+		scorerResult, err := scorer.Score(ctx, example)
+		if err != nil {
+			scorerResult = &ScorerResult{
+				Name:  scorer.GetName(),
+				Error: err.Error(),
+			}
+		} else {
+			scorerResult.NormalizedScore = scoreRangeOf(scorer).Normalize(scorerResult.Score)
+		}
+		exampleResult.Results = append(exampleResult.Results, scorerResult)
+	}
+
+	exampleResult.Duration = time.Since(start)
+	return exampleResult
+}
+
+func (e *Evaluation) report(evalName string, scorers []LocalScorer, results []*ExampleResult) (string, error) {
+	run := models.ExampleEvaluationRun{
+		Id:              uuid.New().String(),
+		ProjectId:       e.projectID,
+		EvalName:        evalName,
+		CreatedAt:       time.Now().UTC().Format(time.RFC3339),
+		Examples:        make([]models.Example, 0, len(results)),
+		JudgmentScorers: []models.ScorerConfig{},
+		CustomScorers:   make([]models.BaseScorer, 0, len(scorers)),
+	}
+
+	for _, scorer := range scorers {
+		config := scorer.GetScorerConfig()
+		run.CustomScorers = append(run.CustomScorers, models.BaseScorer{
+			ScoreType:      config.ScoreType,
+			Name:           config.Name,
+			RequiredParams: config.RequiredParams,
+		})
+	}
+
+	scoringResults := make([]models.ScoringResult, 0, len(results))
+	for _, r := range results {
+		scorersData := make([]map[string]any, 0, len(r.Results))
+		for _, sr := range r.Results {
+			scorersData = append(scorersData, sr.toScorerData())
+		}
+
+		example := r.Example.toModel()
+		run.Examples = append(run.Examples, example)
+		scoringResults = append(scoringResults, models.ExampleScoringResult{
+			ScorersData: scorersData,
+			DataObject:  example,
+			RunDuration: r.Duration.Seconds(),
+			AdditionalProperties: map[string]any{
+				"success": r.Success(),
+			},
+		})
+	}
+
+	resp, err := e.client.PostProjectsEvalResults(e.projectID, &models.LogEvalResultsRequest{
+		Results: scoringResults,
+		Run:     run,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to report evaluation results for '%s': %w", evalName, err)
+	}
+
+	logger.Info("Reported %d evaluation results for %s", len(results), evalName)
+	return resp.UiResultsUrl, nil
+}
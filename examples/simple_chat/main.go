@@ -9,6 +9,7 @@ import (
 	v1 "github.com/JudgmentLabs/judgeval-go"
 	"github.com/openai/openai-go"
 	oaioption "github.com/openai/openai-go/option"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func main() {
@@ -71,30 +72,29 @@ func main() {
 }
 
 func callLLM(ctx context.Context, tracer *v1.Tracer, apiKey string, userInput string) string {
-	llmCtx, llmSpan := tracer.Span(ctx, "llm-call")
-	defer llmSpan.End()
-
-	tracer.SetLLMSpan(llmSpan)
-	tracer.SetInput(llmSpan, userInput)
-
-	openaiClient := openai.NewClient(oaioption.WithAPIKey(apiKey))
-
-	messages := []openai.ChatCompletionMessageParamUnion{
-		openai.SystemMessage("You are a helpful assistant."),
-		openai.UserMessage(userInput),
-	}
-
-	response, err := openaiClient.Chat.Completions.New(llmCtx, openai.ChatCompletionNewParams{
-		Model:    openai.ChatModelGPT4o,
-		Messages: messages,
+	output, err := v1.Observe(ctx, tracer, "llm-call", userInput, func(ctx context.Context, input string) (string, error) {
+		tracer.SetLLMSpan(trace.SpanFromContext(ctx))
+
+		openaiClient := openai.NewClient(oaioption.WithAPIKey(apiKey))
+
+		messages := []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage("You are a helpful assistant."),
+			openai.UserMessage(input),
+		}
+
+		response, err := openaiClient.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+			Model:    openai.ChatModelGPT4o,
+			Messages: messages,
+		})
+		if err != nil {
+			return "", err
+		}
+		return response.Choices[0].Message.Content, nil
 	})
 	if err != nil {
 		fmt.Printf("Error: Chat completion failed: %v\n", err)
 		os.Exit(1)
 	}
 
-	output := response.Choices[0].Message.Content
-	tracer.SetOutput(llmSpan, output)
-
 	return output
 }
@@ -50,6 +50,25 @@ func (e *JudgmentSpanExporter) Shutdown(ctx context.Context) error {
 	return e.delegate.Shutdown(ctx)
 }
 
+// exportHook is told about every batch handed to an exporter, with the
+// export error if the batch was not exported.
+type exportHook func(ctx context.Context, spans []sdktrace.ReadOnlySpan, err error)
+
+// hookedSpanExporter runs its hooks after each export attempt of the
+// delegate.
+type hookedSpanExporter struct {
+	sdktrace.SpanExporter
+	afterExport []exportHook
+}
+
+func (e *hookedSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	for _, hook := range e.afterExport {
+		hook(ctx, spans, err)
+	}
+	return err
+}
+
 type NoOpSpanExporter struct{}
 
 func NewNoOpSpanExporter() sdktrace.SpanExporter {
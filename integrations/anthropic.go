@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	judgeval "github.com/JudgmentLabs/judgeval-go"
 	"go.opentelemetry.io/otel/attribute"
@@ -43,8 +45,8 @@ func anthropicInstrumentedRoundTrip(tracer judgeval.JudgevalTracerLike, req *htt
 
 	spanName := anthropicGetSpanName(req.URL.Path)
 
+	start := time.Now()
 	ctx, span := tracer.StartSpan(ctx, spanName)
-	defer span.End()
 
 	span.SetAttributes(attribute.String(judgeval.AttributeKeysJudgmentSpanKind, "llm"))
 	span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAISystem, "anthropic"))
@@ -61,8 +63,8 @@ func anthropicInstrumentedRoundTrip(tracer judgeval.JudgevalTracerLike, req *htt
 
 	resp, err := next(req)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		tracer.SetError(span, err)
+		span.End()
 		return resp, err
 	}
 
@@ -70,14 +72,16 @@ func anthropicInstrumentedRoundTrip(tracer judgeval.JudgevalTracerLike, req *htt
 		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
 	}
 
-	if isStreaming {
-		resp.Body = newAnthropicStreamingResponseBody(resp.Body, span)
+	// A streamed response ends its span once the body has been consumed.
+	if isStreaming && resp.Body != nil {
+		resp.Body = newAnthropicStreamingResponseBody(resp.Body, tracer, span, start)
 	} else {
+		defer span.End()
 		if resp.Body != nil {
 			responseBody, err := io.ReadAll(resp.Body)
 			if err == nil {
 				resp.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-				setAnthropicResponseAttributes(span, responseBody)
+				setAnthropicResponseAttributes(tracer, span, responseBody)
 			}
 		}
 	}
@@ -121,7 +125,7 @@ func setAnthropicRequestAttributes(span trace.Span, data map[string]interface{})
 	}
 }
 
-func setAnthropicResponseAttributes(span trace.Span, body []byte) {
+func setAnthropicResponseAttributes(tracer judgeval.JudgevalTracerLike, span trace.Span, body []byte) {
 	var data map[string]interface{}
 	if err := json.Unmarshal(body, &data); err != nil {
 		return
@@ -130,37 +134,38 @@ func setAnthropicResponseAttributes(span trace.Span, body []byte) {
 	if id, ok := data["id"].(string); ok {
 		span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAIResponseID, id))
 	}
+	llmUsage := judgeval.LLMUsage{Provider: "anthropic"}
 	if model, ok := data["model"].(string); ok {
 		span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAIResponseModel, model))
+		llmUsage.Model = model
 	}
 	if stopReason, ok := data["stop_reason"].(string); ok {
 		span.SetAttributes(attribute.StringSlice(judgeval.AttributeKeysGenAIResponseFinishReasons, []string{stopReason}))
 	}
 
 	if usage, ok := data["usage"].(map[string]interface{}); ok {
-		if inputTokens, ok := usage["input_tokens"].(float64); ok {
-			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageInputTokens, int(inputTokens)))
-		}
-		if outputTokens, ok := usage["output_tokens"].(float64); ok {
-			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageOutputTokens, int(outputTokens)))
-		}
-		if cacheCreation, ok := usage["cache_creation_input_tokens"].(float64); ok {
-			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageCacheCreationInputTokens, int(cacheCreation)))
-		}
-		if cacheRead, ok := usage["cache_read_input_tokens"].(float64); ok {
-			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageCacheReadInputTokens, int(cacheRead)))
+		setAnthropicUsageAttributes(span, usage, &llmUsage)
+		if llmUsage.Model != "" {
+			tracer.RecordLLMCost(span, llmUsage)
 		}
 	}
 
 	if content, ok := data["content"].([]interface{}); ok && len(content) > 0 {
 		var texts []string
+		var toolCallIDs []string
 		for _, item := range content {
 			if itemMap, ok := item.(map[string]interface{}); ok {
 				if text, ok := itemMap["text"].(string); ok {
 					texts = append(texts, text)
 				}
+				if id, ok := anthropicToolUseID(itemMap); ok {
+					toolCallIDs = append(toolCallIDs, id)
+				}
 			}
 		}
+		if len(toolCallIDs) > 0 {
+			span.SetAttributes(attribute.StringSlice(judgeval.AttributeKeysGenAIResponseToolCallIDs, toolCallIDs))
+		}
 		if len(texts) > 0 {
 			if jsonBytes, err := json.Marshal(texts); err == nil {
 				span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAICompletion, string(jsonBytes)))
@@ -169,16 +174,51 @@ func setAnthropicResponseAttributes(span trace.Span, body []byte) {
 	}
 }
 
+func anthropicToolUseID(block map[string]interface{}) (string, bool) {
+	if block["type"] != "tool_use" {
+		return "", false
+	}
+	id, ok := block["id"].(string)
+	return id, ok && id != ""
+}
+
+// setAnthropicUsageAttributes records the token counts present in usage and
+// copies them into llmUsage. Streaming responses report usage across several
+// events, so absent counts leave llmUsage unchanged.
+func setAnthropicUsageAttributes(span trace.Span, usage map[string]interface{}, llmUsage *judgeval.LLMUsage) {
+	if inputTokens, ok := usage["input_tokens"].(float64); ok {
+		span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageInputTokens, int(inputTokens)))
+		llmUsage.InputTokens = int(inputTokens)
+	}
+	if outputTokens, ok := usage["output_tokens"].(float64); ok {
+		span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageOutputTokens, int(outputTokens)))
+		llmUsage.OutputTokens = int(outputTokens)
+	}
+	if cacheCreation, ok := usage["cache_creation_input_tokens"].(float64); ok {
+		span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageCacheCreationInputTokens, int(cacheCreation)))
+		llmUsage.CacheCreationInputTokens = int(cacheCreation)
+	}
+	if cacheRead, ok := usage["cache_read_input_tokens"].(float64); ok {
+		span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageCacheReadInputTokens, int(cacheRead)))
+		llmUsage.CacheReadInputTokens = int(cacheRead)
+	}
+}
+
 type anthropicStreamingResponseBody struct {
 	body        io.ReadCloser
+	tracer      judgeval.JudgevalTracerLike
 	span        trace.Span
+	timer       *streamTimer
 	accumulated strings.Builder
+	finalize    sync.Once
 }
 
-func newAnthropicStreamingResponseBody(body io.ReadCloser, span trace.Span) *anthropicStreamingResponseBody {
+func newAnthropicStreamingResponseBody(body io.ReadCloser, tracer judgeval.JudgevalTracerLike, span trace.Span, start time.Time) *anthropicStreamingResponseBody {
 	return &anthropicStreamingResponseBody{
-		body: body,
-		span: span,
+		body:   body,
+		tracer: tracer,
+		span:   span,
+		timer:  newStreamTimer(span, start, anthropicIsContentLine),
 	}
 }
 
@@ -186,23 +226,29 @@ func (s *anthropicStreamingResponseBody) Read(p []byte) (int, error) {
 	n, err := s.body.Read(p)
 	if n > 0 {
 		s.accumulated.Write(p[:n])
+		s.timer.observe(p[:n])
 	}
 	if err == io.EOF {
-		s.finalizeSpan()
+		s.finalize.Do(s.finalizeSpan)
+	} else if err != nil {
+		s.tracer.SetError(s.span, err)
+		s.finalize.Do(s.finalizeSpan)
 	}
 	return n, err
 }
 
 func (s *anthropicStreamingResponseBody) Close() error {
-	if s.accumulated.Len() > 0 {
-		s.finalizeSpan()
-	}
+	s.finalize.Do(s.finalizeSpan)
 	return s.body.Close()
 }
 
 func (s *anthropicStreamingResponseBody) finalizeSpan() {
+	defer s.span.End()
+
 	lines := strings.Split(s.accumulated.String(), "\n")
 	var contentParts []string
+	var toolCallIDs []string
+	llmUsage := judgeval.LLMUsage{Provider: "anthropic"}
 
 	for _, line := range lines {
 		if !strings.HasPrefix(line, "data: ") {
@@ -218,6 +264,12 @@ func (s *anthropicStreamingResponseBody) finalizeSpan() {
 		eventType, _ := event["type"].(string)
 
 		switch eventType {
+		case "content_block_start":
+			if block, ok := event["content_block"].(map[string]interface{}); ok {
+				if id, ok := anthropicToolUseID(block); ok {
+					toolCallIDs = append(toolCallIDs, id)
+				}
+			}
 		case "content_block_delta":
 			if delta, ok := event["delta"].(map[string]interface{}); ok {
 				if text, ok := delta["text"].(string); ok {
@@ -231,22 +283,19 @@ func (s *anthropicStreamingResponseBody) finalizeSpan() {
 				}
 			}
 			if usage, ok := event["usage"].(map[string]interface{}); ok {
-				if outputTokens, ok := usage["output_tokens"].(float64); ok {
-					s.span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageOutputTokens, int(outputTokens)))
-				}
+				setAnthropicUsageAttributes(s.span, usage, &llmUsage)
 			}
 		case "message_start":
 			if message, ok := event["message"].(map[string]interface{}); ok {
 				if model, ok := message["model"].(string); ok {
 					s.span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAIResponseModel, model))
+					llmUsage.Model = model
 				}
 				if id, ok := message["id"].(string); ok {
 					s.span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAIResponseID, id))
 				}
 				if usage, ok := message["usage"].(map[string]interface{}); ok {
-					if inputTokens, ok := usage["input_tokens"].(float64); ok {
-						s.span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageInputTokens, int(inputTokens)))
-					}
+					setAnthropicUsageAttributes(s.span, usage, &llmUsage)
 				}
 			}
 		}
@@ -256,4 +305,24 @@ func (s *anthropicStreamingResponseBody) finalizeSpan() {
 		fullContent := strings.Join(contentParts, "")
 		s.span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAICompletion, fullContent))
 	}
+	if len(toolCallIDs) > 0 {
+		s.span.SetAttributes(attribute.StringSlice(judgeval.AttributeKeysGenAIResponseToolCallIDs, toolCallIDs))
+	}
+
+	if llmUsage.Model != "" {
+		s.tracer.RecordLLMCost(s.span, llmUsage)
+	}
+	s.timer.finish(llmUsage.OutputTokens)
+}
+
+func anthropicIsContentLine(line []byte) bool {
+	data, ok := sseData(line)
+	if !ok {
+		return false
+	}
+	var event map[string]interface{}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return false
+	}
+	return event["type"] == "content_block_delta"
 }
@@ -13,6 +13,10 @@
 //	    option.WithMiddleware(integrations.OpenAIMiddleware(tracer)),
 //	)
 //
+// Streamed chat completions report token usage, and so cost and the output
+// token rate, only when the request sets stream_options.include_usage.
+// Without it the rate is estimated from the number of content deltas.
+//
 // Anthropic Usage:
 //
 //	import (
@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	judgeval "github.com/JudgmentLabs/judgeval-go"
 	"go.opentelemetry.io/otel/attribute"
@@ -43,8 +45,8 @@ func openaiInstrumentedRoundTrip(tracer judgeval.JudgevalTracerLike, req *http.R
 
 	spanName := openaiGetSpanName(req.URL.Path)
 
+	start := time.Now()
 	ctx, span := tracer.StartSpan(ctx, spanName)
-	defer span.End()
 
 	span.SetAttributes(attribute.String(judgeval.AttributeKeysJudgmentSpanKind, "llm"))
 
@@ -60,8 +62,8 @@ func openaiInstrumentedRoundTrip(tracer judgeval.JudgevalTracerLike, req *http.R
 
 	resp, err := next(req)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		tracer.SetError(span, err)
+		span.End()
 		return resp, err
 	}
 
@@ -69,14 +71,16 @@ func openaiInstrumentedRoundTrip(tracer judgeval.JudgevalTracerLike, req *http.R
 		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
 	}
 
-	if isStreaming {
-		resp.Body = newOpenAIStreamingResponseBody(resp.Body, span)
+	// A streamed response ends its span once the body has been consumed.
+	if isStreaming && resp.Body != nil {
+		resp.Body = newOpenAIStreamingResponseBody(resp.Body, tracer, span, start)
 	} else {
+		defer span.End()
 		if resp.Body != nil {
 			responseBody, err := io.ReadAll(resp.Body)
 			if err == nil {
 				resp.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-				setOpenAIResponseAttributes(span, responseBody)
+				setOpenAIResponseAttributes(tracer, span, responseBody)
 			}
 		}
 	}
@@ -127,7 +131,7 @@ func setOpenAIRequestAttributes(span trace.Span, data map[string]interface{}) {
 	}
 }
 
-func setOpenAIResponseAttributes(span trace.Span, body []byte) {
+func setOpenAIResponseAttributes(tracer judgeval.JudgevalTracerLike, span trace.Span, body []byte) {
 	var data map[string]interface{}
 	if err := json.Unmarshal(body, &data); err != nil {
 		return
@@ -136,41 +140,21 @@ func setOpenAIResponseAttributes(span trace.Span, body []byte) {
 	if id, ok := data["id"].(string); ok {
 		span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAIResponseID, id))
 	}
+	llmUsage := judgeval.LLMUsage{Provider: "openai"}
 	if model, ok := data["model"].(string); ok {
 		span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAIResponseModel, model))
+		llmUsage.Model = model
 	}
 
 	if usage, ok := data["usage"].(map[string]interface{}); ok {
-		if inputTokens, ok := usage["input_tokens"].(float64); ok {
-			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageInputTokens, int(inputTokens)))
-		} else if promptTokens, ok := usage["prompt_tokens"].(float64); ok {
-			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageInputTokens, int(promptTokens)))
-		}
-
-		if outputTokens, ok := usage["output_tokens"].(float64); ok {
-			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageOutputTokens, int(outputTokens)))
-		} else if completionTokens, ok := usage["completion_tokens"].(float64); ok {
-			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageOutputTokens, int(completionTokens)))
-		}
-
-		if totalTokens, ok := usage["total_tokens"].(float64); ok {
-			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageTotalTokens, int(totalTokens)))
-		}
-
-		if inputDetails, ok := usage["input_tokens_details"].(map[string]interface{}); ok {
-			if cachedTokens, ok := inputDetails["cached_tokens"].(float64); ok {
-				span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageCacheReadInputTokens, int(cachedTokens)))
-			}
-		} else if promptDetails, ok := usage["prompt_tokens_details"].(map[string]interface{}); ok {
-			if cachedTokens, ok := promptDetails["cached_tokens"].(float64); ok {
-				span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageCacheReadInputTokens, int(cachedTokens)))
-			}
-		}
+		setOpenAIUsageAttributes(span, usage, &llmUsage)
+		recordOpenAICost(tracer, span, llmUsage)
 	}
 
 	if choices, ok := data["choices"].([]interface{}); ok && len(choices) > 0 {
 		finishReasons := make([]string, 0, len(choices))
 		var completions []string
+		var toolCallIDs []string
 
 		for _, choice := range choices {
 			if choiceMap, ok := choice.(map[string]interface{}); ok {
@@ -181,6 +165,7 @@ func setOpenAIResponseAttributes(span trace.Span, body []byte) {
 					if content, ok := message["content"].(string); ok {
 						completions = append(completions, content)
 					}
+					toolCallIDs = appendOpenAIToolCallIDs(toolCallIDs, message)
 				}
 				if text, ok := choiceMap["text"].(string); ok {
 					completions = append(completions, text)
@@ -191,6 +176,9 @@ func setOpenAIResponseAttributes(span trace.Span, body []byte) {
 		if len(finishReasons) > 0 {
 			span.SetAttributes(attribute.StringSlice(judgeval.AttributeKeysGenAIResponseFinishReasons, finishReasons))
 		}
+		if len(toolCallIDs) > 0 {
+			span.SetAttributes(attribute.StringSlice(judgeval.AttributeKeysGenAIResponseToolCallIDs, toolCallIDs))
+		}
 		if len(completions) > 0 {
 			if jsonBytes, err := json.Marshal(completions); err == nil {
 				span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAICompletion, string(jsonBytes)))
@@ -200,8 +188,14 @@ func setOpenAIResponseAttributes(span trace.Span, body []byte) {
 
 	if output, ok := data["output"].([]interface{}); ok && len(output) > 0 {
 		var texts []string
+		var toolCallIDs []string
 		for _, item := range output {
 			if itemMap, ok := item.(map[string]interface{}); ok {
+				if itemMap["type"] == "function_call" {
+					if callID, ok := itemMap["call_id"].(string); ok {
+						toolCallIDs = append(toolCallIDs, callID)
+					}
+				}
 				if content, ok := itemMap["content"].([]interface{}); ok {
 					for _, c := range content {
 						if cMap, ok := c.(map[string]interface{}); ok {
@@ -218,19 +212,88 @@ func setOpenAIResponseAttributes(span trace.Span, body []byte) {
 				span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAICompletion, string(jsonBytes)))
 			}
 		}
+		if len(toolCallIDs) > 0 {
+			span.SetAttributes(attribute.StringSlice(judgeval.AttributeKeysGenAIResponseToolCallIDs, toolCallIDs))
+		}
+	}
+}
+
+// setOpenAIUsageAttributes records token usage in either the Chat
+// Completions or the Responses API format.
+func setOpenAIUsageAttributes(span trace.Span, usage map[string]interface{}, llmUsage *judgeval.LLMUsage) {
+	if inputTokens, ok := usage["input_tokens"].(float64); ok {
+		span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageInputTokens, int(inputTokens)))
+		llmUsage.InputTokens = int(inputTokens)
+	} else if promptTokens, ok := usage["prompt_tokens"].(float64); ok {
+		span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageInputTokens, int(promptTokens)))
+		llmUsage.InputTokens = int(promptTokens)
+	}
+
+	if outputTokens, ok := usage["output_tokens"].(float64); ok {
+		span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageOutputTokens, int(outputTokens)))
+		llmUsage.OutputTokens = int(outputTokens)
+	} else if completionTokens, ok := usage["completion_tokens"].(float64); ok {
+		span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageOutputTokens, int(completionTokens)))
+		llmUsage.OutputTokens = int(completionTokens)
+	}
+
+	if totalTokens, ok := usage["total_tokens"].(float64); ok {
+		span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageTotalTokens, int(totalTokens)))
+	}
+
+	if inputDetails, ok := usage["input_tokens_details"].(map[string]interface{}); ok {
+		if cachedTokens, ok := inputDetails["cached_tokens"].(float64); ok {
+			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageCacheReadInputTokens, int(cachedTokens)))
+			llmUsage.CacheReadInputTokens = int(cachedTokens)
+		}
+	} else if promptDetails, ok := usage["prompt_tokens_details"].(map[string]interface{}); ok {
+		if cachedTokens, ok := promptDetails["cached_tokens"].(float64); ok {
+			span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageCacheReadInputTokens, int(cachedTokens)))
+			llmUsage.CacheReadInputTokens = int(cachedTokens)
+		}
+	}
+}
+
+// appendOpenAIToolCallIDs appends the IDs of the tool calls in a chat
+// completion message or streamed delta. Streamed calls carry their ID only
+// in their first delta.
+func appendOpenAIToolCallIDs(ids []string, message map[string]interface{}) []string {
+	toolCalls, _ := message["tool_calls"].([]interface{})
+	for _, toolCall := range toolCalls {
+		if toolCallMap, ok := toolCall.(map[string]interface{}); ok {
+			if id, ok := toolCallMap["id"].(string); ok && id != "" {
+				ids = append(ids, id)
+			}
+		}
 	}
+	return ids
+}
+
+// recordOpenAICost prices usage after removing cached tokens from the input
+// count, since OpenAI reports prompt tokens inclusive of the cached ones.
+func recordOpenAICost(tracer judgeval.JudgevalTracerLike, span trace.Span, usage judgeval.LLMUsage) {
+	if usage.Model == "" {
+		return
+	}
+	usage.InputTokens = max(usage.InputTokens-usage.CacheReadInputTokens, 0)
+	tracer.RecordLLMCost(span, usage)
 }
 
 type openaiStreamingResponseBody struct {
 	body        io.ReadCloser
+	tracer      judgeval.JudgevalTracerLike
 	span        trace.Span
+	timer       *streamTimer
 	accumulated strings.Builder
+	finalize    sync.Once
 }
 
-func newOpenAIStreamingResponseBody(body io.ReadCloser, span trace.Span) *openaiStreamingResponseBody {
+func newOpenAIStreamingResponseBody(body io.ReadCloser, tracer judgeval.JudgevalTracerLike, span trace.Span, start time.Time) *openaiStreamingResponseBody {
 	return &openaiStreamingResponseBody{
-		body: body,
-		span: span,
+		body:   body,
+		tracer: tracer,
+		span:   span,
+		timer:  newStreamTimer(span, start, openaiIsContentLine),
 	}
 }
 
@@ -238,23 +301,35 @@ func (s *openaiStreamingResponseBody) Read(p []byte) (int, error) {
 	n, err := s.body.Read(p)
 	if n > 0 {
 		s.accumulated.Write(p[:n])
+		s.timer.observe(p[:n])
 	}
 	if err == io.EOF {
-		s.finalizeSpan()
+		s.finalize.Do(s.finalizeSpan)
+	} else if err != nil {
+		s.tracer.SetError(s.span, err)
+		s.finalize.Do(s.finalizeSpan)
 	}
 	return n, err
 }
 
 func (s *openaiStreamingResponseBody) Close() error {
-	if s.accumulated.Len() > 0 {
-		s.finalizeSpan()
-	}
+	s.finalize.Do(s.finalizeSpan)
 	return s.body.Close()
 }
 
+// finalizeSpan records what the stream carried. Chat completion streams only
+// report usage when the request sets stream_options.include_usage; without
+// it the output rate is estimated by counting each content delta as one
+// token, which is how OpenAI streams text.
 func (s *openaiStreamingResponseBody) finalizeSpan() {
+	defer s.span.End()
+
 	lines := strings.Split(s.accumulated.String(), "\n")
 	var contentParts []string
+	var toolCallIDs []string
+	contentDeltas := 0
+	llmUsage := judgeval.LLMUsage{Provider: "openai"}
+	hasUsage := false
 
 	for _, line := range lines {
 		if !strings.HasPrefix(line, "data: ") {
@@ -270,38 +345,96 @@ func (s *openaiStreamingResponseBody) finalizeSpan() {
 			continue
 		}
 
+		switch chunk["type"] {
+		case "response.output_text.delta":
+			if delta, ok := chunk["delta"].(string); ok {
+				contentParts = append(contentParts, delta)
+				contentDeltas++
+			}
+		case "response.completed":
+			// The Responses API reports the final response, usage included,
+			// in its last event.
+			if response, ok := chunk["response"].(map[string]interface{}); ok {
+				if id, ok := response["id"].(string); ok {
+					s.span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAIResponseID, id))
+				}
+				if model, ok := response["model"].(string); ok {
+					s.span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAIResponseModel, model))
+					llmUsage.Model = model
+				}
+				if usage, ok := response["usage"].(map[string]interface{}); ok {
+					hasUsage = true
+					setOpenAIUsageAttributes(s.span, usage, &llmUsage)
+				}
+			}
+		}
+
 		// Extract content from choices delta
 		if choices, ok := chunk["choices"].([]interface{}); ok {
 			for _, choice := range choices {
 				if choiceMap, ok := choice.(map[string]interface{}); ok {
 					if delta, ok := choiceMap["delta"].(map[string]interface{}); ok {
-						if content, ok := delta["content"].(string); ok {
+						if content, ok := delta["content"].(string); ok && content != "" {
 							contentParts = append(contentParts, content)
+							contentDeltas++
 						}
+						toolCallIDs = appendOpenAIToolCallIDs(toolCallIDs, delta)
 					}
 				}
 			}
 		}
 
 		if usage, ok := chunk["usage"].(map[string]interface{}); ok {
-			if promptTokens, ok := usage["prompt_tokens"].(float64); ok {
-				s.span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageInputTokens, int(promptTokens)))
-			}
-			if completionTokens, ok := usage["completion_tokens"].(float64); ok {
-				s.span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageOutputTokens, int(completionTokens)))
-			}
-			if totalTokens, ok := usage["total_tokens"].(float64); ok {
-				s.span.SetAttributes(attribute.Int(judgeval.AttributeKeysGenAIUsageTotalTokens, int(totalTokens)))
-			}
+			hasUsage = true
+			setOpenAIUsageAttributes(s.span, usage, &llmUsage)
 		}
 
 		if model, ok := chunk["model"].(string); ok {
 			s.span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAIResponseModel, model))
+			llmUsage.Model = model
 		}
 	}
 
+	if hasUsage {
+		recordOpenAICost(s.tracer, s.span, llmUsage)
+		s.timer.finish(llmUsage.OutputTokens)
+	} else {
+		s.timer.finish(contentDeltas)
+	}
+
 	if len(contentParts) > 0 {
 		fullContent := strings.Join(contentParts, "")
 		s.span.SetAttributes(attribute.String(judgeval.AttributeKeysGenAICompletion, fullContent))
 	}
+	if len(toolCallIDs) > 0 {
+		s.span.SetAttributes(attribute.StringSlice(judgeval.AttributeKeysGenAIResponseToolCallIDs, toolCallIDs))
+	}
+}
+
+// openaiIsContentLine reports whether a streamed line carries generated
+// content, either a chat completion delta or a Responses API text delta.
+func openaiIsContentLine(line []byte) bool {
+	data, ok := sseData(line)
+	if !ok {
+		return false
+	}
+	var chunk map[string]interface{}
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return false
+	}
+	if chunk["type"] == "response.output_text.delta" {
+		return true
+	}
+	choices, _ := chunk["choices"].([]interface{})
+	for _, choice := range choices {
+		choiceMap, _ := choice.(map[string]interface{})
+		delta, _ := choiceMap["delta"].(map[string]interface{})
+		if content, ok := delta["content"].(string); ok && content != "" {
+			return true
+		}
+		if toolCalls, ok := delta["tool_calls"].([]interface{}); ok && len(toolCalls) > 0 {
+			return true
+		}
+	}
+	return false
 }
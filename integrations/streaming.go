@@ -0,0 +1,75 @@
+package integrations
+
+import (
+	"bytes"
+	"time"
+
+	judgeval "github.com/JudgmentLabs/judgeval-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// streamTimer times a streamed response. It watches the raw stream for the
+// first server-sent event line that carries content, recording a
+// first_token event when it arrives, and derives the streaming metrics once
+// the stream ends.
+type streamTimer struct {
+	span       trace.Span
+	start      time.Time
+	firstToken time.Time
+	partial    []byte
+	isContent  func(line []byte) bool
+}
+
+func newStreamTimer(span trace.Span, start time.Time, isContent func(line []byte) bool) *streamTimer {
+	return &streamTimer{span: span, start: start, isContent: isContent}
+}
+
+func (t *streamTimer) observe(chunk []byte) {
+	if !t.firstToken.IsZero() {
+		return
+	}
+
+	t.partial = append(t.partial, chunk...)
+	for {
+		i := bytes.IndexByte(t.partial, '\n')
+		if i < 0 {
+			return
+		}
+		line := bytes.TrimSpace(t.partial[:i])
+		t.partial = t.partial[i+1:]
+		if t.isContent(line) {
+			t.firstToken = time.Now()
+			t.partial = nil
+			ttft := durationMs(t.firstToken.Sub(t.start))
+			t.span.AddEvent("first_token", trace.WithTimestamp(t.firstToken), trace.WithAttributes(
+				attribute.Float64(judgeval.AttributeKeysJudgmentStreamingTimeToFirstTokenMs, ttft),
+			))
+			t.span.SetAttributes(attribute.Float64(judgeval.AttributeKeysJudgmentStreamingTimeToFirstTokenMs, ttft))
+			return
+		}
+	}
+}
+
+// finish records the total streaming duration and, when the output token
+// count is known, the output rate measured from the first token.
+func (t *streamTimer) finish(outputTokens int) {
+	end := time.Now()
+	t.span.SetAttributes(attribute.Float64(judgeval.AttributeKeysJudgmentStreamingDurationMs, durationMs(end.Sub(t.start))))
+
+	if t.firstToken.IsZero() || outputTokens <= 0 {
+		return
+	}
+	if generation := end.Sub(t.firstToken); generation > 0 {
+		t.span.SetAttributes(attribute.Float64(judgeval.AttributeKeysJudgmentStreamingOutputTokensPerSecond, float64(outputTokens)/generation.Seconds()))
+	}
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// sseData returns the payload of a server-sent event data line.
+func sseData(line []byte) ([]byte, bool) {
+	return bytes.CutPrefix(line, []byte("data: "))
+}
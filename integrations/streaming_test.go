@@ -0,0 +1,158 @@
+package integrations
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	judgeval "github.com/JudgmentLabs/judgeval-go"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeTracer records LLM costs and leaves every other method of
+// JudgevalTracerLike unimplemented.
+type fakeTracer struct {
+	judgeval.JudgevalTracerLike
+	costs []judgeval.LLMUsage
+}
+
+func (f *fakeTracer) RecordLLMCost(span trace.Span, usage judgeval.LLMUsage) (float64, bool) {
+	f.costs = append(f.costs, usage)
+	return 0, false
+}
+
+func (f *fakeTracer) SetError(span trace.Span, err error) {}
+
+func newTestSpan(t *testing.T) (trace.Span, func() sdktrace.ReadOnlySpan) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	_, span := provider.Tracer("test").Start(context.Background(), "stream")
+	return span, func() sdktrace.ReadOnlySpan {
+		ended := recorder.Ended()
+		if len(ended) != 1 {
+			t.Fatalf("ended %d spans, want 1", len(ended))
+		}
+		return ended[0]
+	}
+}
+
+func attributeOf(s sdktrace.ReadOnlySpan, key string) (attribute.Value, bool) {
+	for _, attr := range s.Attributes() {
+		if string(attr.Key) == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestStreamTimerWaitsForCompleteLine(t *testing.T) {
+	span, ended := newTestSpan(t)
+	timer := newStreamTimer(span, time.Now(), openaiIsContentLine)
+
+	line := `data: {"choices":[{"delta":{"content":"Hi"}}]}` + "\n"
+	chunks := []string{"data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n", line[:10], line[10:30], line[30:]}
+	for i, chunk := range chunks {
+		timer.observe([]byte(chunk))
+		if complete := i == len(chunks)-1; timer.firstToken.IsZero() == complete {
+			t.Fatalf("after chunk %d first token recorded = %v, want %v", i, !timer.firstToken.IsZero(), complete)
+		}
+	}
+	span.End()
+
+	events := ended().Events()
+	if len(events) != 1 || events[0].Name != "first_token" {
+		t.Fatalf("events = %+v, want one first_token event", events)
+	}
+}
+
+func TestOpenAIStreamingTiming(t *testing.T) {
+	tests := []struct {
+		name        string
+		stream      string
+		wantOutput  int64
+		wantContent string
+		wantCosts   int
+	}{
+		{
+			name: "chat completions with usage",
+			stream: `data: {"model":"gpt-4o","choices":[{"delta":{"role":"assistant"}}]}` + "\n\n" +
+				`data: {"model":"gpt-4o","choices":[{"delta":{"content":"Hello"}}]}` + "\n\n" +
+				`data: {"model":"gpt-4o","choices":[{"delta":{"content":" world"}}]}` + "\n\n" +
+				`data: {"model":"gpt-4o","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}` + "\n\n" +
+				"data: [DONE]\n\n",
+			wantOutput:  2,
+			wantContent: "Hello world",
+			wantCosts:   1,
+		},
+		{
+			name: "chat completions without usage",
+			stream: `data: {"model":"gpt-4o","choices":[{"delta":{"content":"Hello"}}]}` + "\n\n" +
+				`data: {"model":"gpt-4o","choices":[{"delta":{"content":" world"}}]}` + "\n\n" +
+				`data: {"model":"gpt-4o","choices":[{"delta":{"content":"!"}}]}` + "\n\n" +
+				"data: [DONE]\n\n",
+			wantContent: "Hello world!",
+		},
+		{
+			name: "responses",
+			stream: "event: response.created\n" + `data: {"type":"response.created","response":{"id":"resp_1"}}` + "\n\n" +
+				"event: response.output_text.delta\n" + `data: {"type":"response.output_text.delta","delta":"Hello"}` + "\n\n" +
+				"event: response.output_text.delta\n" + `data: {"type":"response.output_text.delta","delta":" world"}` + "\n\n" +
+				"event: response.completed\n" + `data: {"type":"response.completed","response":{"id":"resp_1","model":"gpt-4o","usage":{"input_tokens":5,"output_tokens":4,"total_tokens":9,"input_tokens_details":{"cached_tokens":1}}}}` + "\n\n",
+			wantOutput:  4,
+			wantContent: "Hello world",
+			wantCosts:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span, ended := newTestSpan(t)
+			tracer := &fakeTracer{}
+			// One byte per read splits every event across many reads.
+			raw := io.NopCloser(iotest.OneByteReader(strings.NewReader(tt.stream)))
+			body := newOpenAIStreamingResponseBody(raw, tracer, span, time.Now())
+			if _, err := io.Copy(io.Discard, body); err != nil {
+				t.Fatalf("read stream: %v", err)
+			}
+			body.Close()
+			s := ended()
+
+			firstTokens := 0
+			for _, event := range s.Events() {
+				if event.Name == "first_token" {
+					firstTokens++
+				}
+			}
+			if firstTokens != 1 {
+				t.Errorf("got %d first_token events, want 1", firstTokens)
+			}
+			if _, ok := attributeOf(s, judgeval.AttributeKeysJudgmentStreamingTimeToFirstTokenMs); !ok {
+				t.Error("time to first token not recorded")
+			}
+			if rate, ok := attributeOf(s, judgeval.AttributeKeysJudgmentStreamingOutputTokensPerSecond); !ok || rate.AsFloat64() <= 0 {
+				t.Errorf("output tokens per second = %v, %v; want a positive rate", rate.AsFloat64(), ok)
+			}
+
+			output, ok := attributeOf(s, judgeval.AttributeKeysGenAIUsageOutputTokens)
+			if tt.wantOutput == 0 && ok {
+				t.Errorf("output tokens = %d, want none without usage", output.AsInt64())
+			} else if tt.wantOutput != 0 && output.AsInt64() != tt.wantOutput {
+				t.Errorf("output tokens = %d, want %d", output.AsInt64(), tt.wantOutput)
+			}
+			if completion, _ := attributeOf(s, judgeval.AttributeKeysGenAICompletion); completion.AsString() != tt.wantContent {
+				t.Errorf("completion = %q, want %q", completion.AsString(), tt.wantContent)
+			}
+			if len(tracer.costs) != tt.wantCosts {
+				t.Errorf("recorded %d costs, want %d", len(tracer.costs), tt.wantCosts)
+			}
+		})
+	}
+}
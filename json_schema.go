@@ -0,0 +1,240 @@
+package judgeval
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+)
+
+// normalizeJSONSchema converts a schema given as a JSON string, raw bytes or
+// any JSON-marshalable Go value into its decoded map form. Go values always
+// make a JSON round trip, even maps, so nested []string or int values come
+// back as the []any and float64 the validator expects.
+func normalizeJSONSchema(schema any) (map[string]any, error) {
+	var raw []byte
+	switch s := schema.(type) {
+	case nil:
+		return nil, errors.New("schema is required")
+	case string:
+		raw = []byte(s)
+	case []byte:
+		raw = s
+	case json.RawMessage:
+		raw = s
+	default:
+		b, err := json.Marshal(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal schema: %w", err)
+		}
+		raw = b
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	return decoded, nil
+}
+
+// validateJSONSchema checks value against the subset of JSON Schema most
+// commonly used for structured LLM output: type, enum, const, properties,
+// required, additionalProperties, items, min/max bounds, pattern, and the
+// allOf/anyOf/oneOf/not combinators. It returns one message per violation.
+func validateJSONSchema(schema map[string]any, value any, path string) []string {
+	var errs []string
+
+	if types, ok := schemaTypes(schema["type"]); ok {
+		if !slices.ContainsFunc(types, func(t string) bool { return jsonTypeMatches(t, value) }) {
+			return append(errs, fmt.Sprintf("%s: expected type %v, got %s", path, types, jsonTypeName(value)))
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		if !slices.ContainsFunc(enum, func(e any) bool { return reflect.DeepEqual(e, value) }) {
+			errs = append(errs, fmt.Sprintf("%s: value is not one of %v", path, enum))
+		}
+	}
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, value) {
+		errs = append(errs, fmt.Sprintf("%s: value must equal %v", path, c))
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		errs = append(errs, validateJSONObject(schema, v, path)...)
+	case []any:
+		errs = append(errs, validateJSONArray(schema, v, path)...)
+	case string:
+		length := float64(len([]rune(v)))
+		if lower, ok := schema["minLength"].(float64); ok && length < lower {
+			errs = append(errs, fmt.Sprintf("%s: length %d is less than %v", path, int(length), lower))
+		}
+		if upper, ok := schema["maxLength"].(float64); ok && length > upper {
+			errs = append(errs, fmt.Sprintf("%s: length %d is greater than %v", path, int(length), upper))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: invalid pattern %q: %v", path, pattern, err))
+			} else if !re.MatchString(v) {
+				errs = append(errs, fmt.Sprintf("%s: value does not match pattern %q", path, pattern))
+			}
+		}
+	case float64:
+		if lower, ok := schema["minimum"].(float64); ok && v < lower {
+			errs = append(errs, fmt.Sprintf("%s: %v is less than minimum %v", path, v, lower))
+		}
+		if upper, ok := schema["maximum"].(float64); ok && v > upper {
+			errs = append(errs, fmt.Sprintf("%s: %v is greater than maximum %v", path, v, upper))
+		}
+		if lower, ok := schema["exclusiveMinimum"].(float64); ok && v <= lower {
+			errs = append(errs, fmt.Sprintf("%s: %v is not greater than %v", path, v, lower))
+		}
+		if upper, ok := schema["exclusiveMaximum"].(float64); ok && v >= upper {
+			errs = append(errs, fmt.Sprintf("%s: %v is not less than %v", path, v, upper))
+		}
+	}
+
+	if all, ok := schema["allOf"].([]any); ok {
+		for _, sub := range all {
+			if subSchema, ok := sub.(map[string]any); ok {
+				errs = append(errs, validateJSONSchema(subSchema, value, path)...)
+			}
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok && countMatchingSchemas(anyOf, value, path) == 0 {
+		errs = append(errs, fmt.Sprintf("%s: value does not match any schema in anyOf", path))
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		if n := countMatchingSchemas(oneOf, value, path); n != 1 {
+			errs = append(errs, fmt.Sprintf("%s: value matches %d schemas in oneOf, expected exactly 1", path, n))
+		}
+	}
+	if not, ok := schema["not"].(map[string]any); ok && len(validateJSONSchema(not, value, path)) == 0 {
+		errs = append(errs, fmt.Sprintf("%s: value must not match schema in not", path))
+	}
+
+	return errs
+}
+
+func validateJSONObject(schema map[string]any, obj map[string]any, path string) []string {
+	var errs []string
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, present := obj[name]; !present {
+					errs = append(errs, fmt.Sprintf("%s: missing required property %q", path, name))
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := path + "." + k
+		if propSchema, ok := properties[k].(map[string]any); ok {
+			errs = append(errs, validateJSONSchema(propSchema, obj[k], childPath)...)
+			continue
+		}
+		if _, declared := properties[k]; declared {
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				errs = append(errs, fmt.Sprintf("%s: additional property %q is not allowed", path, k))
+			}
+		case map[string]any:
+			errs = append(errs, validateJSONSchema(additional, obj[k], childPath)...)
+		}
+	}
+
+	return errs
+}
+
+func validateJSONArray(schema map[string]any, arr []any, path string) []string {
+	var errs []string
+
+	length := float64(len(arr))
+	if lower, ok := schema["minItems"].(float64); ok && length < lower {
+		errs = append(errs, fmt.Sprintf("%s: %d items is less than %v", path, len(arr), lower))
+	}
+	if upper, ok := schema["maxItems"].(float64); ok && length > upper {
+		errs = append(errs, fmt.Sprintf("%s: %d items is greater than %v", path, len(arr), upper))
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		for i, item := range arr {
+			errs = append(errs, validateJSONSchema(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+
+	return errs
+}
+
+func countMatchingSchemas(schemas []any, value any, path string) int {
+	matches := 0
+	for _, sub := range schemas {
+		if subSchema, ok := sub.(map[string]any); ok && len(validateJSONSchema(subSchema, value, path)) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+func schemaTypes(raw any) ([]string, bool) {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}, true
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types, len(types) > 0
+	}
+	return nil, false
+}
+
+func jsonTypeMatches(schemaType string, value any) bool {
+	switch schemaType {
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonTypeName(value) == schemaType
+	}
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,125 @@
+package judgeval
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeJSONSchema(t *testing.T) {
+	type property struct {
+		Type    string `json:"type"`
+		Maximum int    `json:"maximum"`
+	}
+	type schema struct {
+		Type       string              `json:"type"`
+		Required   []string            `json:"required"`
+		Properties map[string]property `json:"properties"`
+	}
+
+	want := `{"a":{"type":"integer","maximum":3}}`
+	tests := []struct {
+		name   string
+		schema any
+	}{
+		{name: "string", schema: `{"type":"object","required":["a"],"properties":` + want + `}`},
+		{name: "bytes", schema: []byte(`{"type":"object","required":["a"],"properties":` + want + `}`)},
+		{name: "raw message", schema: json.RawMessage(`{"type":"object","required":["a"],"properties":` + want + `}`)},
+		{name: "struct", schema: schema{Type: "object", Required: []string{"a"}, Properties: map[string]property{"a": {Type: "integer", Maximum: 3}}}},
+		{name: "typed map", schema: map[string]any{
+			"type":       "object",
+			"required":   []string{"a"},
+			"properties": map[string]any{"a": map[string]any{"type": "integer", "maximum": 3}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := normalizeJSONSchema(tt.schema)
+			if err != nil {
+				t.Fatalf("normalizeJSONSchema() error = %v", err)
+			}
+			if errs := validateJSONSchema(normalized, map[string]any{}, "$"); len(errs) != 1 || !strings.Contains(errs[0], `missing required property "a"`) {
+				t.Errorf("validating {} = %v, want missing required property", errs)
+			}
+			if errs := validateJSONSchema(normalized, map[string]any{"a": 5.0}, "$"); len(errs) != 1 || !strings.Contains(errs[0], "greater than maximum") {
+				t.Errorf(`validating {"a":5} = %v, want maximum violation`, errs)
+			}
+		})
+	}
+}
+
+func TestNormalizeJSONSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema any
+	}{
+		{name: "nil", schema: nil},
+		{name: "invalid json", schema: `{"type":`},
+		{name: "not an object", schema: `["string"]`},
+		{name: "unmarshalable", schema: map[string]any{"f": func() {}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := normalizeJSONSchema(tt.schema); err == nil {
+				t.Errorf("normalizeJSONSchema(%v) error = nil, want error", tt.name)
+			}
+		})
+	}
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     string
+		value      string
+		wantErrors []string
+	}{
+		{name: "type match", schema: `{"type":"string"}`, value: `"x"`},
+		{name: "type mismatch", schema: `{"type":"string"}`, value: `1`, wantErrors: []string{"expected type [string], got number"}},
+		{name: "union type", schema: `{"type":["string","null"]}`, value: `null`},
+		{name: "integer accepts whole number", schema: `{"type":"integer"}`, value: `2.0`},
+		{name: "integer rejects fraction", schema: `{"type":"integer"}`, value: `2.5`, wantErrors: []string{"expected type [integer]"}},
+		{name: "enum", schema: `{"enum":["a","b"]}`, value: `"c"`, wantErrors: []string{"is not one of"}},
+		{name: "const", schema: `{"const":3}`, value: `3`},
+		{name: "string bounds", schema: `{"minLength":2,"maxLength":3}`, value: `"abcd"`, wantErrors: []string{"length 4 is greater than 3"}},
+		{name: "pattern", schema: `{"pattern":"^[a-z]+$"}`, value: `"ab1"`, wantErrors: []string{"does not match pattern"}},
+		{name: "exclusive bounds", schema: `{"exclusiveMinimum":0,"exclusiveMaximum":1}`, value: `1`, wantErrors: []string{"is not less than 1"}},
+		{
+			name:       "nested object",
+			schema:     `{"type":"object","properties":{"a":{"type":"object","properties":{"b":{"type":"number"}}}},"additionalProperties":false}`,
+			value:      `{"a":{"b":"x"},"c":1}`,
+			wantErrors: []string{"$.a.b: expected type [number]", `additional property "c" is not allowed`},
+		},
+		{name: "additional property schema", schema: `{"additionalProperties":{"type":"string"}}`, value: `{"x":1}`, wantErrors: []string{"$.x: expected type [string]"}},
+		{name: "array items", schema: `{"items":{"type":"string"},"maxItems":2}`, value: `["a",1,"c"]`, wantErrors: []string{"3 items is greater than 2", "$[1]: expected type [string]"}},
+		{name: "anyOf", schema: `{"anyOf":[{"type":"string"},{"type":"number"}]}`, value: `true`, wantErrors: []string{"does not match any schema in anyOf"}},
+		{name: "oneOf ambiguous", schema: `{"oneOf":[{"type":"number"},{"minimum":0}]}`, value: `1`, wantErrors: []string{"matches 2 schemas in oneOf"}},
+		{name: "allOf", schema: `{"allOf":[{"minimum":0},{"maximum":10}]}`, value: `11`, wantErrors: []string{"greater than maximum 10"}},
+		{name: "not", schema: `{"not":{"type":"null"}}`, value: `null`, wantErrors: []string{"must not match schema in not"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := normalizeJSONSchema(tt.schema)
+			if err != nil {
+				t.Fatalf("normalizeJSONSchema() error = %v", err)
+			}
+			var value any
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatalf("invalid test value %s: %v", tt.value, err)
+			}
+
+			errs := validateJSONSchema(schema, value, "$")
+			if len(errs) != len(tt.wantErrors) {
+				t.Fatalf("validateJSONSchema() = %v, want %d errors", errs, len(tt.wantErrors))
+			}
+			for i, want := range tt.wantErrors {
+				if !strings.Contains(errs[i], want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
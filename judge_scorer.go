@@ -0,0 +1,391 @@
+package judgeval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/JudgmentLabs/judgeval-go/env"
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+	"github.com/JudgmentLabs/judgeval-go/logger"
+)
+
+const (
+	defaultJudgeBaseURL     = "https://api.openai.com/v1"
+	defaultJudgeMaxAttempts = 3
+)
+
+// JudgeBackend points a JudgeScorer at an OpenAI-compatible chat completions
+// endpoint, such as OpenAI, a self-hosted vLLM server, or a local mock.
+type JudgeBackend struct {
+	// BaseURL defaults to https://api.openai.com/v1. Requests are sent to
+	// BaseURL + "/chat/completions".
+	BaseURL *string
+	// APIKey defaults to the OPENAI_API_KEY environment variable. No
+	// Authorization header is sent when it is empty.
+	APIKey *string
+	// Model defaults to JUDGMENT_DEFAULT_GPT_MODEL.
+	Model      *string
+	HTTPClient *http.Client
+	// Temperature defaults to 0.
+	Temperature *float64
+}
+
+type JudgeScorerParams struct {
+	Name *string
+	// Prompt may reference Example properties as {{field}}.
+	Prompt string
+	// Options maps each label the judge may choose to its score. When set,
+	// the judge picks a choice instead of returning a score. Binary scorers
+	// default to {"yes": 1, "no": 0}.
+	Options    map[string]float64
+	Threshold  *float64
+	ResultType *ScorerResultType
+	// Range declares the scale numeric judges score on, such as 1-5 for a
+	// Likert prompt. Defaults to DefaultScoreRange; when set, Threshold
+	// defaults to its midpoint.
+	Range   *ScoreRange
+	Backend JudgeBackend
+	// MaxAttempts bounds how many times the judge is asked in total, counting
+	// the first request and each retry after a malformed verdict. Defaults
+	// to 3.
+	MaxAttempts *int
+}
+
+// JudgeScorer is an LLM-as-judge scorer that runs locally against an
+// OpenAI-compatible backend instead of on the platform.
+type JudgeScorer struct {
+	name        string
+	prompt      string
+	options     map[string]float64
+	threshold   float64
+	resultType  ScorerResultType
+	scoreRange  ScoreRange
+	baseURL     string
+	apiKey      string
+	model       string
+	temperature float64
+	httpClient  *http.Client
+	maxAttempts int
+}
+
+var _ LocalScorer = (*JudgeScorer)(nil)
+
+var judgePromptFieldPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+func (f *LocalScorersFactory) Judge(params JudgeScorerParams) (*JudgeScorer, error) {
+	if params.Prompt == "" {
+		return nil, errors.New("judge scorer prompt is required")
+	}
+
+	resultType := ScorerResultTypeNumeric
+	if params.ResultType != nil {
+		resultType = *params.ResultType
+	}
+	if !resultType.valid() {
+		return nil, fmt.Errorf("judge scorer has unknown result type '%s'", resultType)
+	}
+
+	options := maps.Clone(params.Options)
+	if len(options) == 0 && resultType == ScorerResultTypeBinary {
+		options = map[string]float64{"yes": 1, "no": 0}
+	}
+	if len(options) == 0 && resultType == ScorerResultTypeCategorical {
+		return nil, errors.New("categorical judge scorers require options")
+	}
+
+	scoreRange := DefaultScoreRange
+	if params.Range != nil {
+		if err := params.Range.validate(); err != nil {
+			return nil, fmt.Errorf("judge scorer has invalid score range: %w", err)
+		}
+		scoreRange = *params.Range
+	}
+
+	maxAttempts := getInt(params.MaxAttempts, defaultJudgeMaxAttempts)
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("judge scorer max attempts must be at least 1, got %d", maxAttempts)
+	}
+
+	httpClient := params.Backend.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &JudgeScorer{
+		name:        getString(params.Name, "Judge"),
+		prompt:      params.Prompt,
+		options:     options,
+		threshold:   getFloat(params.Threshold, scoreRange.midpoint()),
+		resultType:  resultType,
+		scoreRange:  scoreRange,
+		baseURL:     strings.TrimSuffix(getString(params.Backend.BaseURL, defaultJudgeBaseURL), "/"),
+		apiKey:      getString(params.Backend.APIKey, os.Getenv("OPENAI_API_KEY")),
+		model:       getString(params.Backend.Model, env.JudgmentDefaultGPTModel),
+		temperature: getFloat(params.Backend.Temperature, 0),
+		httpClient:  httpClient,
+		maxAttempts: maxAttempts,
+	}, nil
+}
+
+// JudgeFromPromptScorer runs a platform prompt scorer's prompt, options,
+// threshold and result type locally against backend. The scorer's model is
+// used unless backend sets one.
+func (f *LocalScorersFactory) JudgeFromPromptScorer(scorer *PromptScorer, backend JudgeBackend) (*JudgeScorer, error) {
+	if backend.Model == nil {
+		backend.Model = String(scorer.model)
+	}
+	resultType := scorer.resultType
+	scoreRange := scorer.scoreRange
+	return f.Judge(JudgeScorerParams{
+		Name:       String(scorer.name),
+		Prompt:     scorer.prompt,
+		Options:    scorer.options,
+		Threshold:  Float(scorer.threshold),
+		ResultType: &resultType,
+		Range:      &scoreRange,
+		Backend:    backend,
+	})
+}
+
+func (s *JudgeScorer) GetName() string {
+	return s.name
+}
+
+func (s *JudgeScorer) GetThreshold() float64 {
+	return s.threshold
+}
+
+func (s *JudgeScorer) GetResultType() ScorerResultType {
+	return s.resultType
+}
+
+func (s *JudgeScorer) GetScoreRange() ScoreRange {
+	return s.scoreRange
+}
+
+// ScoreForLabel returns the score mapped to a category label by the
+// judge's options. Labels match case-insensitively.
+func (s *JudgeScorer) ScoreForLabel(label string) (float64, bool) {
+	return scoreForLabel(s.options, label)
+}
+
+func (s *JudgeScorer) GetScorerConfig() *models.ScorerConfig {
+	kwargs := map[string]any{
+		"prompt": s.prompt,
+		"model":  s.model,
+	}
+	if len(s.options) > 0 {
+		kwargs["options"] = s.options
+	}
+	s.scoreRange.toKwargs(kwargs)
+
+	return &models.ScorerConfig{
+		ScoreType:      "Local Judge",
+		Name:           s.name,
+		Threshold:      s.threshold,
+		RequiredParams: s.requiredParams(),
+		Kwargs:         kwargs,
+		ResultType:     s.resultType.String(),
+	}
+}
+
+func (s *JudgeScorer) requiredParams() []string {
+	var fields []string
+	for _, match := range judgePromptFieldPattern.FindAllStringSubmatch(s.prompt, -1) {
+		if !slices.Contains(fields, match[1]) {
+			fields = append(fields, match[1])
+		}
+	}
+	return fields
+}
+
+// Score renders the prompt against the example and asks the judge for a
+// verdict. Malformed verdicts are retried, with the parse error fed back to
+// the judge, until MaxAttempts requests have been made; transport errors are
+// returned as-is.
+func (s *JudgeScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	prompt, err := s.render(example)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []judgeMessage{
+		{Role: "system", Content: s.instructions()},
+		{Role: "user", Content: prompt},
+	}
+
+	var parseErr error
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		content, err := s.complete(ctx, messages)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := s.parseVerdict(content)
+		if err == nil {
+			return result, nil
+		}
+
+		parseErr = err
+		logger.Debug("Judge %s returned a malformed verdict (attempt %d/%d): %v", s.name, attempt, s.maxAttempts, err)
+		messages = append(messages,
+			judgeMessage{Role: "assistant", Content: content},
+			judgeMessage{Role: "user", Content: fmt.Sprintf("Your response was invalid: %v. Respond again with only the JSON object.", err)},
+		)
+	}
+	return nil, fmt.Errorf("judge %s returned a malformed verdict after %d attempts: %w", s.name, s.maxAttempts, parseErr)
+}
+
+func (s *JudgeScorer) render(example *Example) (string, error) {
+	var missing error
+	rendered := judgePromptFieldPattern.ReplaceAllStringFunc(s.prompt, func(match string) string {
+		field := judgePromptFieldPattern.FindStringSubmatch(match)[1]
+		value, err := exampleString(example, field)
+		if err != nil {
+			missing = errors.Join(missing, err)
+			return match
+		}
+		return value
+	})
+	if missing != nil {
+		return "", missing
+	}
+	return rendered, nil
+}
+
+func (s *JudgeScorer) instructions() string {
+	if len(s.options) > 0 {
+		choices := slices.Sorted(maps.Keys(s.options))
+		return fmt.Sprintf(`You are an evaluator. Respond with only a JSON object of the form {"choice": <one of %s>, "reason": <string>}.`,
+			strings.Join(quoteAll(choices), ", "))
+	}
+	direction := "higher"
+	if s.scoreRange.LowerIsBetter {
+		direction = "lower"
+	}
+	return fmt.Sprintf(`You are an evaluator. Respond with only a JSON object of the form {"score": <number between %v and %v, where %s is better>, "reason": <string>}.`,
+		s.scoreRange.Min, s.scoreRange.Max, direction)
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return quoted
+}
+
+type judgeVerdict struct {
+	Score  *float64 `json:"score"`
+	Reason string   `json:"reason"`
+	Choice *string  `json:"choice"`
+}
+
+func (s *JudgeScorer) parseVerdict(content string) (*ScorerResult, error) {
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, errors.New("no JSON object found")
+	}
+
+	var verdict judgeVerdict
+	if err := json.Unmarshal([]byte(content[start:end+1]), &verdict); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	result := &ScorerResult{
+		Name:      s.name,
+		Threshold: s.threshold,
+		Reason:    verdict.Reason,
+	}
+
+	if len(s.options) > 0 {
+		if verdict.Choice == nil {
+			return nil, errors.New(`missing "choice"`)
+		}
+		score, ok := scoreForLabel(s.options, *verdict.Choice)
+		if !ok {
+			return nil, fmt.Errorf("choice %q is not one of the allowed options", *verdict.Choice)
+		}
+		result.Score = score
+		result.Label = *verdict.Choice
+	} else {
+		if verdict.Score == nil {
+			return nil, errors.New(`missing "score"`)
+		}
+		if !s.scoreRange.Contains(*verdict.Score) {
+			return nil, fmt.Errorf("score %v is outside [%v, %v]", *verdict.Score, s.scoreRange.Min, s.scoreRange.Max)
+		}
+		result.Score = *verdict.Score
+	}
+
+	result.Success = s.scoreRange.Passes(result.Score, s.threshold)
+	result.NormalizedScore = s.scoreRange.Normalize(result.Score)
+	return result, nil
+}
+
+type judgeMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type judgeChatRequest struct {
+	Model       string         `json:"model"`
+	Messages    []judgeMessage `json:"messages"`
+	Temperature float64        `json:"temperature"`
+}
+
+type judgeChatResponse struct {
+	Choices []struct {
+		Message judgeMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (s *JudgeScorer) complete(ctx context.Context, messages []judgeMessage) (string, error) {
+	body, err := json.Marshal(judgeChatRequest{
+		Model:       s.model,
+		Messages:    messages,
+		Temperature: s.temperature,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call judge %s: %w", s.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("judge %s returned HTTP %d: %s", s.name, resp.StatusCode, string(respBody))
+	}
+
+	var chat judgeChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chat); err != nil {
+		return "", fmt.Errorf("failed to decode judge %s response: %w", s.name, err)
+	}
+	if len(chat.Choices) == 0 {
+		return "", fmt.Errorf("judge %s returned no choices", s.name)
+	}
+	return chat.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,73 @@
+package judgeval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestJudge serves chat completions from replies, one per request.
+func newTestJudge(t *testing.T, maxAttempts int, replies ...string) (*JudgeScorer, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		var req judgeChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		n := int(calls.Add(1))
+		if want := 2 * n; len(req.Messages) != want {
+			t.Errorf("request %d has %d messages, want %d", n, len(req.Messages), want)
+		}
+		reply := replies[min(n, len(replies))-1]
+		json.NewEncoder(w).Encode(map[string]any{
+			"choices": []any{map[string]any{"message": map[string]string{"role": "assistant", "content": reply}}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	judge, err := (&LocalScorersFactory{}).Judge(JudgeScorerParams{
+		Prompt:      "Rate {{actual_output}}",
+		MaxAttempts: Int(maxAttempts),
+		Backend:     JudgeBackend{BaseURL: String(server.URL), APIKey: String("key"), Model: String("model")},
+	})
+	if err != nil {
+		t.Fatalf("Judge: %v", err)
+	}
+	return judge, &calls
+}
+
+func TestJudgeScorerRetriesMalformedVerdict(t *testing.T) {
+	judge, calls := newTestJudge(t, 3, "not json", `{"score": 0.8, "reason": "good"}`)
+
+	result, err := judge.Score(context.Background(), NewExample(ExampleParams{"actual_output": "hi"}))
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("judge asked %d times, want 2", got)
+	}
+	if result.Score != 0.8 || !result.Success || result.Reason != "good" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestJudgeScorerMaxAttemptsCountsTotalRequests(t *testing.T) {
+	judge, calls := newTestJudge(t, 2, "not json", `{"score": 7}`, `{"score": 0.8}`)
+
+	_, err := judge.Score(context.Background(), NewExample(ExampleParams{"actual_output": "hi"}))
+	if err == nil || !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Errorf("Score error = %v, want a malformed verdict error after 2 attempts", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("judge asked %d times, want 2", got)
+	}
+}
@@ -41,9 +41,13 @@ func NewJudgeval(projectName string, opts ...Option) (*Judgeval, error) {
 	}
 
 	apiClient := api.NewClient(cfg.apiURL, cfg.apiKey, cfg.orgID)
-	projectID, err := resolveProjectID(apiClient, projectName)
-	if err != nil {
-		return nil, err
+	projectID := cfg.projectID
+	if projectID == "" {
+		var err error
+		projectID, err = resolveProjectID(apiClient, projectName)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &Judgeval{
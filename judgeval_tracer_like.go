@@ -12,7 +12,7 @@ type JudgevalTracerLike interface {
 	Shutdown(ctx context.Context) error
 
 	GetTracer() trace.Tracer
-	Span(ctx context.Context, spanName string) (context.Context, trace.Span)
+	Span(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span)
 	SetSpanKind(span trace.Span, kind string)
 	SetLLMSpan(span trace.Span)
 	SetToolSpan(span trace.Span)
@@ -21,10 +21,19 @@ type JudgevalTracerLike interface {
 	SetAttributes(span trace.Span, attrs map[string]interface{})
 	SetInput(span trace.Span, input interface{})
 	SetOutput(span trace.Span, output interface{})
+	SetError(span trace.Span, err error)
+	RecordPanic(span trace.Span, recovered any)
+	TrackState(ctx context.Context, span trace.Span, before any) StateFinalizer
+	RecordLLMCost(span trace.Span, usage LLMUsage) (float64, bool)
 	SetCustomerID(ctx context.Context, customerID string) context.Context
 	SetSessionID(ctx context.Context, sessionID string) context.Context
+	TagTrace(ctx context.Context, tags ...string)
+	TagTraceByID(ctx context.Context, traceID string, tags ...string) error
+	ToolCall(ctx context.Context, toolName string, args any, fn func(ctx context.Context) (any, error), opts ...ToolCallOption) (any, error)
+	StartFanIn(ctx context.Context, spanName string, workers []trace.SpanContext, opts ...trace.SpanStartOption) (context.Context, trace.Span)
+	StartAgent(ctx context.Context, className string, instanceName string) (context.Context, trace.Span)
 	AsyncEvaluate(ctx context.Context, scorer BaseScorer, example *Example)
 	AsyncTraceEvaluate(ctx context.Context, scorer BaseScorer)
-	StartSpan(ctx context.Context, spanName string) (context.Context, trace.Span)
+	StartSpan(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span)
 	EndSpan(span trace.Span)
 }
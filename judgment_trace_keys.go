@@ -1,22 +1,38 @@
 package judgeval
 
 const (
-	AttributeKeysJudgmentSpanKind          = "judgment.span_kind"
-	AttributeKeysJudgmentInput             = "judgment.input"
-	AttributeKeysJudgmentOutput            = "judgment.output"
-	AttributeKeysJudgmentOfflineMode       = "judgment.offline_mode"
-	AttributeKeysJudgmentUpdateID          = "judgment.update_id"
-	AttributeKeysJudgmentCustomerID        = "judgment.customer_id"
-	AttributeKeysJudgmentSessionID         = "judgment.session_id"
-	AttributeKeysJudgmentAgentID           = "judgment.agent_id"
-	AttributeKeysJudgmentParentAgentID     = "judgment.parent_agent_id"
-	AttributeKeysJudgmentAgentClassName    = "judgment.agent_class_name"
-	AttributeKeysJudgmentAgentInstanceName = "judgment.agent_instance_name"
-	AttributeKeysJudgmentIsAgentEntryPoint = "judgment.is_agent_entry_point"
-	AttributeKeysJudgmentCumulativeLLMCost = "judgment.cumulative_llm_cost"
-	AttributeKeysJudgmentStateBefore       = "judgment.state_before"
-	AttributeKeysJudgmentStateAfter        = "judgment.state_after"
-	AttributeKeysPendingTraceEval          = "judgment.pending_trace_eval"
+	AttributeKeysJudgmentSpanKind                       = "judgment.span_kind"
+	AttributeKeysJudgmentInput                          = "judgment.input"
+	AttributeKeysJudgmentOutput                         = "judgment.output"
+	AttributeKeysJudgmentOfflineMode                    = "judgment.offline_mode"
+	AttributeKeysJudgmentUpdateID                       = "judgment.update_id"
+	AttributeKeysJudgmentCustomerID                     = "judgment.customer_id"
+	AttributeKeysJudgmentSessionID                      = "judgment.session_id"
+	AttributeKeysJudgmentAgentID                        = "judgment.agent_id"
+	AttributeKeysJudgmentParentAgentID                  = "judgment.parent_agent_id"
+	AttributeKeysJudgmentAgentClassName                 = "judgment.agent_class_name"
+	AttributeKeysJudgmentAgentInstanceName              = "judgment.agent_instance_name"
+	AttributeKeysJudgmentIsAgentEntryPoint              = "judgment.is_agent_entry_point"
+	AttributeKeysJudgmentCumulativeLLMCost              = "judgment.cumulative_llm_cost"
+	AttributeKeysJudgmentUsageTotalCostUSD              = "judgment.usage.total_cost_usd"
+	AttributeKeysJudgmentStateBefore                    = "judgment.state_before"
+	AttributeKeysJudgmentStateAfter                     = "judgment.state_after"
+	AttributeKeysJudgmentStateDiff                      = "judgment.state_diff"
+	AttributeKeysJudgmentErrorChain                     = "judgment.error_chain"
+	AttributeKeysJudgmentLinkType                       = "judgment.link_type"
+	AttributeKeysJudgmentFanInCount                     = "judgment.fan_in_count"
+	AttributeKeysJudgmentToolSchema                     = "judgment.tool.schema"
+	AttributeKeysJudgmentToolLatencyMs                  = "judgment.tool.latency_ms"
+	AttributeKeysJudgmentStreamingTimeToFirstTokenMs    = "judgment.streaming.time_to_first_token_ms"
+	AttributeKeysJudgmentStreamingDurationMs            = "judgment.streaming.duration_ms"
+	AttributeKeysJudgmentStreamingOutputTokensPerSecond = "judgment.streaming.output_tokens_per_second"
+	AttributeKeysPendingTraceEval                       = "judgment.pending_trace_eval"
+
+	AttributeKeysErrorType           = "error.type"
+	AttributeKeysExceptionType       = "exception.type"
+	AttributeKeysExceptionMessage    = "exception.message"
+	AttributeKeysExceptionStacktrace = "exception.stacktrace"
+	AttributeKeysExceptionEscaped    = "exception.escaped"
 
 	AttributeKeysGenAIPrompt                        = "gen_ai.prompt"
 	AttributeKeysGenAICompletion                    = "gen_ai.completion"
@@ -38,6 +54,11 @@ const (
 	AttributeKeysGenAIRequestStopSequences          = "gen_ai.request.stop_sequences"
 	AttributeKeysGenAIRequestStream                 = "gen_ai.request.stream"
 	AttributeKeysGenAIResponseFinishReasons         = "gen_ai.response.finish_reasons"
+	AttributeKeysGenAIResponseToolCallIDs           = "gen_ai.response.tool_call_ids"
+	AttributeKeysGenAIToolName                      = "gen_ai.tool.name"
+	AttributeKeysGenAIToolCallID                    = "gen_ai.tool.call.id"
+	AttributeKeysGenAIToolCallArguments             = "gen_ai.tool.call.arguments"
+	AttributeKeysGenAIToolCallResult                = "gen_ai.tool.call.result"
 )
 
 const (
@@ -0,0 +1,218 @@
+package judgeval
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ModelPricing is the price of a model in USD per million tokens. Model also
+// prices reported names that extend it with a release date, as -YYYYMMDD or
+// -YYYY-MM-DD: "gpt-4o" prices "gpt-4o-2024-08-06" but not "gpt-4o-mini" or
+// "gpt-4o-latest", which need their own entries. The longest match wins.
+type ModelPricing struct {
+	Provider string
+	Model    string
+	Input    float64
+	Output   float64
+	// CacheRead and CacheCreation default to the Input rate when zero.
+	CacheRead     float64
+	CacheCreation float64
+}
+
+// LLMUsage is the token usage of one LLM call. InputTokens excludes tokens
+// counted in CacheReadInputTokens and CacheCreationInputTokens.
+type LLMUsage struct {
+	Provider                 string
+	Model                    string
+	InputTokens              int
+	OutputTokens             int
+	CacheReadInputTokens     int
+	CacheCreationInputTokens int
+}
+
+var defaultModelPricing = []ModelPricing{
+	{Provider: "openai", Model: "gpt-5", Input: 1.25, Output: 10, CacheRead: 0.125},
+	{Provider: "openai", Model: "gpt-5-mini", Input: 0.25, Output: 2, CacheRead: 0.025},
+	{Provider: "openai", Model: "gpt-5-nano", Input: 0.05, Output: 0.4, CacheRead: 0.005},
+	{Provider: "openai", Model: "gpt-4.1", Input: 2, Output: 8, CacheRead: 0.5},
+	{Provider: "openai", Model: "gpt-4.1-mini", Input: 0.4, Output: 1.6, CacheRead: 0.1},
+	{Provider: "openai", Model: "gpt-4.1-nano", Input: 0.1, Output: 0.4, CacheRead: 0.025},
+	{Provider: "openai", Model: "gpt-4o", Input: 2.5, Output: 10, CacheRead: 1.25},
+	{Provider: "openai", Model: "gpt-4o-mini", Input: 0.15, Output: 0.6, CacheRead: 0.075},
+	{Provider: "openai", Model: "gpt-4-turbo", Input: 10, Output: 30},
+	{Provider: "openai", Model: "gpt-3.5-turbo", Input: 0.5, Output: 1.5},
+	{Provider: "openai", Model: "o1", Input: 15, Output: 60, CacheRead: 7.5},
+	{Provider: "openai", Model: "o3", Input: 2, Output: 8, CacheRead: 0.5},
+	{Provider: "openai", Model: "o3-mini", Input: 1.1, Output: 4.4, CacheRead: 0.55},
+	{Provider: "openai", Model: "o4-mini", Input: 1.1, Output: 4.4, CacheRead: 0.275},
+	{Provider: "anthropic", Model: "claude-opus-4", Input: 15, Output: 75, CacheRead: 1.5, CacheCreation: 18.75},
+	{Provider: "anthropic", Model: "claude-sonnet-4", Input: 3, Output: 15, CacheRead: 0.3, CacheCreation: 3.75},
+	{Provider: "anthropic", Model: "claude-3-7-sonnet", Input: 3, Output: 15, CacheRead: 0.3, CacheCreation: 3.75},
+	{Provider: "anthropic", Model: "claude-3-5-sonnet", Input: 3, Output: 15, CacheRead: 0.3, CacheCreation: 3.75},
+	{Provider: "anthropic", Model: "claude-3-5-haiku", Input: 0.8, Output: 4, CacheRead: 0.08, CacheCreation: 1},
+	{Provider: "anthropic", Model: "claude-3-opus", Input: 15, Output: 75, CacheRead: 1.5, CacheCreation: 18.75},
+	{Provider: "anthropic", Model: "claude-3-haiku", Input: 0.25, Output: 1.25, CacheRead: 0.03, CacheCreation: 0.3},
+}
+
+type pricingTable struct {
+	models map[string]map[string]ModelPricing
+}
+
+var defaultPricingTable = newPricingTable(nil)
+
+// newPricingTable builds a table from the defaults with overrides applied on
+// top, replacing entries for the same provider and model.
+func newPricingTable(overrides []ModelPricing) *pricingTable {
+	t := &pricingTable{models: make(map[string]map[string]ModelPricing)}
+	for _, p := range defaultModelPricing {
+		t.set(p)
+	}
+	for _, p := range overrides {
+		t.set(p)
+	}
+	return t
+}
+
+func (t *pricingTable) set(p ModelPricing) {
+	provider := strings.ToLower(p.Provider)
+	if t.models[provider] == nil {
+		t.models[provider] = make(map[string]ModelPricing)
+	}
+	t.models[provider][strings.ToLower(p.Model)] = p
+}
+
+func (t *pricingTable) lookup(provider, model string) (ModelPricing, bool) {
+	models := t.models[strings.ToLower(provider)]
+	model = strings.ToLower(model)
+	if p, ok := models[model]; ok {
+		return p, true
+	}
+
+	var best ModelPricing
+	bestLen := 0
+	for name, p := range models {
+		if len(name) > bestLen && isModelVersionSuffix(model, name) {
+			best, bestLen = p, len(name)
+		}
+	}
+	return best, bestLen > 0
+}
+
+// isModelVersionSuffix reports whether model is base followed only by a
+// release date, as -YYYYMMDD or -YYYY-MM-DD.
+func isModelVersionSuffix(model, base string) bool {
+	suffix, ok := strings.CutPrefix(model, base+"-")
+	if !ok {
+		return false
+	}
+	for _, layout := range []string{"20060102", "2006-01-02"} {
+		if len(suffix) == len(layout) {
+			if _, err := time.Parse(layout, suffix); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *pricingTable) cost(usage LLMUsage) (float64, bool) {
+	p, ok := t.lookup(usage.Provider, usage.Model)
+	if !ok {
+		return 0, false
+	}
+
+	cacheRead, cacheCreation := p.CacheRead, p.CacheCreation
+	if cacheRead == 0 {
+		cacheRead = p.Input
+	}
+	if cacheCreation == 0 {
+		cacheCreation = p.Input
+	}
+
+	total := float64(usage.InputTokens)*p.Input +
+		float64(usage.OutputTokens)*p.Output +
+		float64(usage.CacheReadInputTokens)*cacheRead +
+		float64(usage.CacheCreationInputTokens)*cacheCreation
+	return total / 1_000_000, true
+}
+
+// RecordLLMCost prices usage with the tracer's pricing table and records the
+// cost on the LLM span. CostProcessor then adds it to every open ancestor's
+// cumulative cost. It reports false when the model has no known price.
+func (b *BaseTracer) RecordLLMCost(span trace.Span, usage LLMUsage) (float64, bool) {
+	pricing := b.pricing
+	if pricing == nil {
+		pricing = defaultPricingTable
+	}
+
+	cost, ok := pricing.cost(usage)
+	if !ok {
+		return 0, false
+	}
+	span.SetAttributes(attribute.Float64(AttributeKeysJudgmentUsageTotalCostUSD, cost))
+	return cost, true
+}
+
+// CostProcessor rolls the cost recorded on LLM spans up into the cumulative
+// cost of each ancestor span that is still open, so the root span carries the
+// cost of the whole trace.
+type CostProcessor struct {
+	mu    sync.Mutex
+	spans map[trace.SpanID]*costSpan
+}
+
+type costSpan struct {
+	span       sdktrace.ReadWriteSpan
+	parent     trace.SpanID
+	cumulative float64
+}
+
+func (p *CostProcessor) ForceFlush(ctx context.Context) error { return nil }
+func (p *CostProcessor) Shutdown(ctx context.Context) error   { return nil }
+
+func (p *CostProcessor) OnStart(parentContext context.Context, span sdktrace.ReadWriteSpan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.spans == nil {
+		p.spans = make(map[trace.SpanID]*costSpan)
+	}
+	p.spans[span.SpanContext().SpanID()] = &costSpan{
+		span:   span,
+		parent: span.Parent().SpanID(),
+	}
+}
+
+func (p *CostProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	cost := 0.0
+	for _, attr := range s.Attributes() {
+		if attr.Key == AttributeKeysJudgmentUsageTotalCostUSD {
+			cost = attr.Value.AsFloat64()
+			break
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.spans, s.SpanContext().SpanID())
+	if cost <= 0 {
+		return
+	}
+
+	for id := s.Parent().SpanID(); id.IsValid(); {
+		ancestor, ok := p.spans[id]
+		if !ok {
+			return
+		}
+		ancestor.cumulative += cost
+		ancestor.span.SetAttributes(attribute.Float64(AttributeKeysJudgmentCumulativeLLMCost, ancestor.cumulative))
+		id = ancestor.parent
+	}
+}
@@ -0,0 +1,101 @@
+package judgeval
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestPricingTableLookup(t *testing.T) {
+	table := newPricingTable([]ModelPricing{{Provider: "openai", Model: "o1-mini", Input: 1.1, Output: 4.4}})
+
+	tests := []struct {
+		provider  string
+		model     string
+		wantModel string
+	}{
+		{provider: "openai", model: "gpt-4o", wantModel: "gpt-4o"},
+		{provider: "OpenAI", model: "GPT-4o", wantModel: "gpt-4o"},
+		{provider: "openai", model: "gpt-4o-2024-08-06", wantModel: "gpt-4o"},
+		{provider: "openai", model: "gpt-4o-mini-2024-07-18", wantModel: "gpt-4o-mini"},
+		{provider: "openai", model: "o1-mini", wantModel: "o1-mini"},
+		{provider: "openai", model: "o1-2024-12-17", wantModel: "o1"},
+		{provider: "openai", model: "o3-pro"},
+		{provider: "openai", model: "o3-pro-2025-06-10"},
+		{provider: "openai", model: "gpt-4o-audio-preview"},
+		{provider: "openai", model: "gpt-5-chat-latest"},
+		{provider: "openai", model: "gpt-4"},
+		{provider: "openai", model: "o1-"},
+		{provider: "anthropic", model: "claude-sonnet-4-20250514", wantModel: "claude-sonnet-4"},
+		{provider: "anthropic", model: "claude-3-5-sonnet-latest"},
+		{provider: "anthropic", model: "claude-opus-4-5-20251101"},
+		{provider: "anthropic", model: "claude-opus-4-1"},
+		{provider: "openai", model: "gpt-4o-20241301"},
+		{provider: "anthropic", model: "gpt-4o"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider+"/"+tt.model, func(t *testing.T) {
+			p, ok := table.lookup(tt.provider, tt.model)
+			if tt.wantModel == "" {
+				if ok {
+					t.Errorf("lookup(%q, %q) = %q, want no match", tt.provider, tt.model, p.Model)
+				}
+				return
+			}
+			if !ok || p.Model != tt.wantModel {
+				t.Errorf("lookup(%q, %q) = (%q, %t), want %q", tt.provider, tt.model, p.Model, ok, tt.wantModel)
+			}
+		})
+	}
+}
+
+func TestCostProcessorRollsUpToOpenAncestors(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(&CostProcessor{}),
+		sdktrace.WithSyncer(exporter),
+	)
+	tracer := provider.Tracer("test")
+	b := &BaseTracer{}
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	childCtx, child := tracer.Start(ctx, "child")
+
+	_, llm := tracer.Start(childCtx, "llm")
+	if _, ok := b.RecordLLMCost(llm, LLMUsage{Provider: "openai", Model: "gpt-4o", InputTokens: 1_000_000}); !ok {
+		t.Fatal("RecordLLMCost found no price for gpt-4o")
+	}
+	llm.End()
+	child.End()
+
+	_, late := tracer.Start(ctx, "late llm")
+	late.SetAttributes(attribute.Float64(AttributeKeysJudgmentUsageTotalCostUSD, 1))
+	late.End()
+	root.End()
+
+	want := map[string]float64{"child": 2.5, "root": 3.5}
+	for _, span := range exporter.GetSpans() {
+		wantCost, ok := want[span.Name]
+		if !ok {
+			continue
+		}
+		got := 0.0
+		for _, attr := range span.Attributes {
+			if attr.Key == AttributeKeysJudgmentCumulativeLLMCost {
+				got = attr.Value.AsFloat64()
+			}
+		}
+		if math.Abs(got-wantCost) > metricTolerance {
+			t.Errorf("%s cumulative cost = %v, want %v", span.Name, got, wantCost)
+		}
+		delete(want, span.Name)
+	}
+	if len(want) > 0 {
+		t.Errorf("spans not exported: %v", want)
+	}
+}
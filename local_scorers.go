@@ -0,0 +1,650 @@
+package judgeval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+	"github.com/JudgmentLabs/judgeval-go/logger"
+)
+
+// LocalScorersFactory builds deterministic scorers that run in-process
+// without an LLM. They read the actual and expected values from Example
+// properties, "actual_output" and "expected_output" by default.
+type LocalScorersFactory struct{}
+
+type LocalScorerParams struct {
+	Name          *string
+	Threshold     *float64
+	ActualField   *string
+	ExpectedField *string
+}
+
+type localScorer struct {
+	scoreType     string
+	resultType    ScorerResultType
+	name          string
+	threshold     float64
+	scoreRange    ScoreRange
+	actualField   string
+	expectedField string
+}
+
+func newLocalScorer(scoreType string, resultType ScorerResultType, params LocalScorerParams) localScorer {
+	return localScorer{
+		scoreType:     scoreType,
+		resultType:    resultType,
+		name:          getString(params.Name, scoreType),
+		threshold:     getFloat(params.Threshold, 0.5),
+		scoreRange:    DefaultScoreRange,
+		actualField:   getString(params.ActualField, "actual_output"),
+		expectedField: getString(params.ExpectedField, "expected_output"),
+	}
+}
+
+func (s *localScorer) GetName() string {
+	return s.name
+}
+
+func (s *localScorer) GetThreshold() float64 {
+	return s.threshold
+}
+
+func (s *localScorer) GetScoreRange() ScoreRange {
+	return s.scoreRange
+}
+
+func (s *localScorer) GetScorerConfig() *models.ScorerConfig {
+	config := &models.ScorerConfig{
+		ScoreType:      s.scoreType,
+		Name:           s.name,
+		Threshold:      s.threshold,
+		RequiredParams: s.requiredParams(),
+		ResultType:     s.resultType.String(),
+	}
+	if !s.scoreRange.isDefault() {
+		config.Kwargs = make(map[string]any)
+		s.scoreRange.toKwargs(config.Kwargs)
+	}
+	return config
+}
+
+func (s *localScorer) requiredParams() []string {
+	if s.expectedField == "" {
+		return []string{s.actualField}
+	}
+	return []string{s.actualField, s.expectedField}
+}
+
+func (s *localScorer) result(score float64, reason string) *ScorerResult {
+	return &ScorerResult{
+		Name:      s.name,
+		Score:     score,
+		Threshold: s.threshold,
+		Success:   s.scoreRange.Passes(score, s.threshold),
+		Reason:    reason,
+	}
+}
+
+func (s *localScorer) actual(example *Example) (string, error) {
+	return exampleString(example, s.actualField)
+}
+
+func (s *localScorer) expected(example *Example) (string, error) {
+	return exampleString(example, s.expectedField)
+}
+
+func exampleString(example *Example, field string) (string, error) {
+	value, ok := example.properties[field]
+	if !ok || value == nil {
+		return "", fmt.Errorf("example is missing required field '%s'", field)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize field '%s': %w", field, err)
+		}
+		return string(b), nil
+	}
+}
+
+func boolScore(ok bool) float64 {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+type ExactMatchScorer struct {
+	localScorer
+}
+
+func (f *LocalScorersFactory) ExactMatch(params LocalScorerParams) *ExactMatchScorer {
+	return &ExactMatchScorer{localScorer: newLocalScorer("Exact Match", ScorerResultTypeBinary, params)}
+}
+
+func (s *ExactMatchScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	actual, err := s.actual(example)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := s.expected(example)
+	if err != nil {
+		return nil, err
+	}
+	return s.result(boolScore(actual == expected), ""), nil
+}
+
+// NormalizedMatchScorer compares values after lowercasing, stripping
+// punctuation and collapsing whitespace.
+type NormalizedMatchScorer struct {
+	localScorer
+}
+
+func (f *LocalScorersFactory) NormalizedMatch(params LocalScorerParams) *NormalizedMatchScorer {
+	return &NormalizedMatchScorer{localScorer: newLocalScorer("Normalized Match", ScorerResultTypeBinary, params)}
+}
+
+func (s *NormalizedMatchScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	actual, err := s.actual(example)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := s.expected(example)
+	if err != nil {
+		return nil, err
+	}
+	return s.result(boolScore(normalizeText(actual) == normalizeText(expected)), ""), nil
+}
+
+type ContainsScorerParams struct {
+	Name            *string
+	Threshold       *float64
+	ActualField     *string
+	ExpectedField   *string
+	Substring       *string
+	CaseInsensitive *bool
+}
+
+// ContainsScorer checks that the actual value contains Substring, or the
+// expected value when no Substring is given.
+type ContainsScorer struct {
+	localScorer
+	substring       *string
+	caseInsensitive bool
+}
+
+func (f *LocalScorersFactory) Contains(params ContainsScorerParams) *ContainsScorer {
+	scorer := &ContainsScorer{
+		localScorer: newLocalScorer("Contains", ScorerResultTypeBinary, LocalScorerParams{
+			Name:          params.Name,
+			Threshold:     params.Threshold,
+			ActualField:   params.ActualField,
+			ExpectedField: params.ExpectedField,
+		}),
+		substring:       params.Substring,
+		caseInsensitive: getBool(params.CaseInsensitive, false),
+	}
+	if scorer.substring != nil {
+		scorer.expectedField = ""
+	}
+	return scorer
+}
+
+func (s *ContainsScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	actual, err := s.actual(example)
+	if err != nil {
+		return nil, err
+	}
+
+	var substring string
+	if s.substring != nil {
+		substring = *s.substring
+	} else if substring, err = s.expected(example); err != nil {
+		return nil, err
+	}
+
+	if s.caseInsensitive {
+		actual, substring = strings.ToLower(actual), strings.ToLower(substring)
+	}
+	return s.result(boolScore(strings.Contains(actual, substring)), ""), nil
+}
+
+type RegexScorerParams struct {
+	Name        *string
+	Threshold   *float64
+	ActualField *string
+	Pattern     string
+}
+
+type RegexScorer struct {
+	localScorer
+	pattern *regexp.Regexp
+}
+
+func (f *LocalScorersFactory) Regex(params RegexScorerParams) (*RegexScorer, error) {
+	pattern, err := regexp.Compile(params.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile regex scorer pattern: %w", err)
+	}
+
+	scorer := &RegexScorer{
+		localScorer: newLocalScorer("Regex", ScorerResultTypeBinary, LocalScorerParams{
+			Name:        params.Name,
+			Threshold:   params.Threshold,
+			ActualField: params.ActualField,
+		}),
+		pattern: pattern,
+	}
+	scorer.expectedField = ""
+	return scorer, nil
+}
+
+func (s *RegexScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	actual, err := s.actual(example)
+	if err != nil {
+		return nil, err
+	}
+	return s.result(boolScore(s.pattern.MatchString(actual)), ""), nil
+}
+
+type JSONValidScorer struct {
+	localScorer
+}
+
+func (f *LocalScorersFactory) JSONValid(params LocalScorerParams) *JSONValidScorer {
+	scorer := &JSONValidScorer{localScorer: newLocalScorer("JSON Valid", ScorerResultTypeBinary, params)}
+	scorer.expectedField = ""
+	return scorer
+}
+
+func (s *JSONValidScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	actual, err := s.actual(example)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	if err := json.Unmarshal([]byte(actual), &decoded); err != nil {
+		return s.result(0, err.Error()), nil
+	}
+	return s.result(1, ""), nil
+}
+
+type JSONSchemaScorerParams struct {
+	Name        *string
+	Threshold   *float64
+	ActualField *string
+	// Schema is a JSON Schema given as a JSON string, raw bytes, a
+	// map[string]any, or any value that marshals to one.
+	Schema any
+}
+
+type JSONSchemaScorer struct {
+	localScorer
+	schema map[string]any
+}
+
+func (f *LocalScorersFactory) JSONSchema(params JSONSchemaScorerParams) (*JSONSchemaScorer, error) {
+	schema, err := normalizeJSONSchema(params.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JSON schema scorer: %w", err)
+	}
+
+	scorer := &JSONSchemaScorer{
+		localScorer: newLocalScorer("JSON Schema", ScorerResultTypeBinary, LocalScorerParams{
+			Name:        params.Name,
+			Threshold:   params.Threshold,
+			ActualField: params.ActualField,
+		}),
+		schema: schema,
+	}
+	scorer.expectedField = ""
+	return scorer, nil
+}
+
+func (s *JSONSchemaScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	actual, err := s.actual(example)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	if err := json.Unmarshal([]byte(actual), &decoded); err != nil {
+		return s.result(0, "invalid JSON: "+err.Error()), nil
+	}
+
+	if violations := validateJSONSchema(s.schema, decoded, "$"); len(violations) > 0 {
+		result := s.result(0, strings.Join(violations, "; "))
+		result.Breakdown = map[string]any{"violations": violations}
+		return result, nil
+	}
+	return s.result(1, ""), nil
+}
+
+type NumericToleranceScorerParams struct {
+	Name              *string
+	Threshold         *float64
+	ActualField       *string
+	ExpectedField     *string
+	AbsoluteTolerance *float64
+	RelativeTolerance *float64
+}
+
+// NumericToleranceScorer passes when the actual number is within the absolute
+// or relative tolerance of the expected number.
+type NumericToleranceScorer struct {
+	localScorer
+	absoluteTolerance float64
+	relativeTolerance float64
+}
+
+func (f *LocalScorersFactory) NumericTolerance(params NumericToleranceScorerParams) *NumericToleranceScorer {
+	return &NumericToleranceScorer{
+		localScorer: newLocalScorer("Numeric Tolerance", ScorerResultTypeBinary, LocalScorerParams{
+			Name:          params.Name,
+			Threshold:     params.Threshold,
+			ActualField:   params.ActualField,
+			ExpectedField: params.ExpectedField,
+		}),
+		absoluteTolerance: getFloat(params.AbsoluteTolerance, 0),
+		relativeTolerance: getFloat(params.RelativeTolerance, 0),
+	}
+}
+
+func (s *NumericToleranceScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	actual, err := s.actual(example)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := s.expected(example)
+	if err != nil {
+		return nil, err
+	}
+
+	actualNum, err := strconv.ParseFloat(strings.TrimSpace(actual), 64)
+	if err != nil {
+		return s.result(0, fmt.Sprintf("actual value %q is not a number", actual)), nil
+	}
+	expectedNum, err := strconv.ParseFloat(strings.TrimSpace(expected), 64)
+	if err != nil {
+		return nil, fmt.Errorf("expected value %q is not a number", expected)
+	}
+
+	diff := math.Abs(actualNum - expectedNum)
+	allowed := math.Max(s.absoluteTolerance, s.relativeTolerance*math.Abs(expectedNum))
+	result := s.result(boolScore(diff <= allowed), "")
+	result.Breakdown = map[string]any{"difference": diff, "allowed": allowed}
+	return result, nil
+}
+
+type LevenshteinScorer struct {
+	localScorer
+}
+
+func (f *LocalScorersFactory) Levenshtein(params LocalScorerParams) *LevenshteinScorer {
+	return &LevenshteinScorer{localScorer: newLocalScorer("Levenshtein Similarity", ScorerResultTypeNumeric, params)}
+}
+
+func (s *LevenshteinScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	actual, err := s.actual(example)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := s.expected(example)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.result(levenshteinSimilarity(actual, expected), "")
+	result.Breakdown = map[string]any{"distance": levenshteinDistance(actual, expected)}
+	return result, nil
+}
+
+type RougeLScorer struct {
+	localScorer
+}
+
+func (f *LocalScorersFactory) RougeL(params LocalScorerParams) *RougeLScorer {
+	return &RougeLScorer{localScorer: newLocalScorer("ROUGE-L", ScorerResultTypeNumeric, params)}
+}
+
+func (s *RougeLScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	actual, err := s.actual(example)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := s.expected(example)
+	if err != nil {
+		return nil, err
+	}
+
+	precision, recall, f1 := rougeL(actual, expected)
+	result := s.result(f1, "")
+	result.Breakdown = map[string]any{"precision": precision, "recall": recall}
+	return result, nil
+}
+
+type BLEUScorerParams struct {
+	Name          *string
+	Threshold     *float64
+	ActualField   *string
+	ExpectedField *string
+	MaxN          *int
+}
+
+type BLEUScorer struct {
+	localScorer
+	maxN int
+}
+
+func (f *LocalScorersFactory) BLEU(params BLEUScorerParams) *BLEUScorer {
+	return &BLEUScorer{
+		localScorer: newLocalScorer("BLEU", ScorerResultTypeNumeric, LocalScorerParams{
+			Name:          params.Name,
+			Threshold:     params.Threshold,
+			ActualField:   params.ActualField,
+			ExpectedField: params.ExpectedField,
+		}),
+		maxN: max(getInt(params.MaxN, 4), 1),
+	}
+}
+
+func (s *BLEUScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	actual, err := s.actual(example)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := s.expected(example)
+	if err != nil {
+		return nil, err
+	}
+	return s.result(bleu(actual, expected, s.maxN), ""), nil
+}
+
+type PropertyScorerParams struct {
+	Name      *string
+	Threshold *float64
+	Field     string
+	// Range declares the scale of numeric values, such as 1-5 for a Likert
+	// rating. Defaults to DefaultScoreRange; when set, Threshold defaults to
+	// its midpoint.
+	Range *ScoreRange
+}
+
+// PropertyScorer scores an example by a boolean or numeric property already
+// present on it, such as a human approval label. true scores 1 and false 0.
+type PropertyScorer struct {
+	localScorer
+}
+
+func (f *LocalScorersFactory) Property(params PropertyScorerParams) *PropertyScorer {
+	scorer := &PropertyScorer{
+		localScorer: newLocalScorer("Property", ScorerResultTypeNumeric, LocalScorerParams{
+			Name:        params.Name,
+			Threshold:   params.Threshold,
+			ActualField: String(params.Field),
+		}),
+	}
+	if params.Name == nil {
+		scorer.name = params.Field
+	}
+	if params.Range != nil {
+		if err := params.Range.validate(); err != nil {
+			logger.Warning("Ignoring score range for scorer %s: %v", scorer.name, err)
+		} else {
+			scorer.scoreRange = *params.Range
+			scorer.threshold = getFloat(params.Threshold, params.Range.midpoint())
+		}
+	}
+	scorer.expectedField = ""
+	return scorer
+}
+
+func (s *PropertyScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	value, ok := example.properties[s.actualField]
+	if !ok || value == nil {
+		return nil, fmt.Errorf("example is missing required field '%s'", s.actualField)
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return s.result(boolScore(v), ""), nil
+	case int:
+		return s.result(float64(v), ""), nil
+	case int64:
+		return s.result(float64(v), ""), nil
+	case float32:
+		return s.result(float64(v), ""), nil
+	case float64:
+		return s.result(v, ""), nil
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return s.result(boolScore(b), ""), nil
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return s.result(f, ""), nil
+		}
+	}
+	return nil, fmt.Errorf("field '%s' has non-boolean, non-numeric value %v", s.actualField, value)
+}
+
+type BudgetScorerParams struct {
+	Name        *string
+	ActualField *string
+	MaxChars    *int
+	MaxWords    *int
+	MaxLatency  *time.Duration
+	// LatencyField names the example property holding the latency, as a
+	// time.Duration, a duration string, or a number of seconds. Defaults to
+	// "latency".
+	LatencyField *string
+}
+
+// BudgetScorer passes when the actual value stays within the configured
+// length budgets and the recorded latency stays within MaxLatency.
+type BudgetScorer struct {
+	localScorer
+	maxChars     *int
+	maxWords     *int
+	maxLatency   *time.Duration
+	latencyField string
+}
+
+func (f *LocalScorersFactory) Budget(params BudgetScorerParams) *BudgetScorer {
+	scorer := &BudgetScorer{
+		localScorer: newLocalScorer("Budget", ScorerResultTypeBinary, LocalScorerParams{
+			Name:        params.Name,
+			Threshold:   Float(1),
+			ActualField: params.ActualField,
+		}),
+		maxChars:     params.MaxChars,
+		maxWords:     params.MaxWords,
+		maxLatency:   params.MaxLatency,
+		latencyField: getString(params.LatencyField, "latency"),
+	}
+	scorer.expectedField = ""
+	return scorer
+}
+
+func (s *BudgetScorer) Score(ctx context.Context, example *Example) (*ScorerResult, error) {
+	breakdown := make(map[string]any)
+	var violations []string
+
+	if s.maxChars != nil || s.maxWords != nil {
+		actual, err := s.actual(example)
+		if err != nil {
+			return nil, err
+		}
+
+		chars := len([]rune(actual))
+		words := len(strings.Fields(actual))
+		breakdown["chars"] = chars
+		breakdown["words"] = words
+
+		if s.maxChars != nil && chars > *s.maxChars {
+			violations = append(violations, fmt.Sprintf("%d chars exceeds budget of %d", chars, *s.maxChars))
+		}
+		if s.maxWords != nil && words > *s.maxWords {
+			violations = append(violations, fmt.Sprintf("%d words exceeds budget of %d", words, *s.maxWords))
+		}
+	}
+
+	if s.maxLatency != nil {
+		latency, err := exampleDuration(example, s.latencyField)
+		if err != nil {
+			return nil, err
+		}
+
+		breakdown["latency_seconds"] = latency.Seconds()
+		if latency > *s.maxLatency {
+			violations = append(violations, fmt.Sprintf("latency %s exceeds budget of %s", latency, *s.maxLatency))
+		}
+	}
+
+	result := s.result(boolScore(len(violations) == 0), strings.Join(violations, "; "))
+	result.Breakdown = breakdown
+	return result, nil
+}
+
+func exampleDuration(example *Example, field string) (time.Duration, error) {
+	value, ok := example.properties[field]
+	if !ok || value == nil {
+		return 0, fmt.Errorf("example is missing required field '%s'", field)
+	}
+
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse field '%s' as a duration: %w", field, err)
+		}
+		return d, nil
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case int64:
+		return time.Duration(v) * time.Second, nil
+	case float32:
+		return time.Duration(float64(v) * float64(time.Second)), nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	default:
+		return 0, fmt.Errorf("field '%s' has unsupported duration type %T", field, value)
+	}
+}
@@ -0,0 +1,44 @@
+package judgeval
+
+import "context"
+
+// Observe runs fn inside a span named name. The input and output are
+// recorded with the tracer's serializer, a returned error is recorded with
+// an error status, and a panic is recorded before being re-raised. The span
+// is marked as a general span; fn may change its kind through
+// trace.SpanFromContext(ctx).
+func Observe[I, O any](ctx context.Context, tracer JudgevalTracerLike, name string, input I, fn func(ctx context.Context, input I) (O, error)) (O, error) {
+	ctx, span := tracer.Span(ctx, name)
+	defer span.End()
+
+	tracer.SetGeneralSpan(span)
+	tracer.SetInput(span, input)
+	defer endSpanOnPanic(tracer, span)
+
+	output, err := fn(ctx, input)
+	if err != nil {
+		tracer.SetError(span, err)
+		return output, err
+	}
+
+	tracer.SetOutput(span, output)
+	return output, nil
+}
+
+// ObserveFunc is Observe for functions that take no input.
+func ObserveFunc[O any](ctx context.Context, tracer JudgevalTracerLike, name string, fn func(ctx context.Context) (O, error)) (O, error) {
+	ctx, span := tracer.Span(ctx, name)
+	defer span.End()
+
+	tracer.SetGeneralSpan(span)
+	defer endSpanOnPanic(tracer, span)
+
+	output, err := fn(ctx)
+	if err != nil {
+		tracer.SetError(span, err)
+		return output, err
+	}
+
+	tracer.SetOutput(span, output)
+	return output, nil
+}
@@ -0,0 +1,68 @@
+package judgeval
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestPanicsAreRecordedOnceAndReraised(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(b *BaseTracer)
+	}{
+		{name: "Observe", run: func(b *BaseTracer) {
+			Observe(context.Background(), &Tracer{BaseTracer: b}, "observe", "in", func(ctx context.Context, input string) (string, error) {
+				panic("boom")
+			})
+		}},
+		{name: "ObserveFunc", run: func(b *BaseTracer) {
+			ObserveFunc(context.Background(), &Tracer{BaseTracer: b}, "observe", func(ctx context.Context) (string, error) {
+				panic("boom")
+			})
+		}},
+		{name: "ToolCall", run: func(b *BaseTracer) {
+			b.ToolCall(context.Background(), "tool", "args", func(ctx context.Context) (any, error) {
+				panic("boom")
+			})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			b := &BaseTracer{serializer: defaultJSONSerializer, tracer: provider.Tracer("test")}
+
+			func() {
+				defer func() {
+					if r := recover(); r != "boom" {
+						t.Errorf("recovered %v, want the original panic", r)
+					}
+				}()
+				tt.run(b)
+			}()
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("exported %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Status.Code != codes.Error {
+				t.Errorf("status = %v, want Error", span.Status)
+			}
+			exceptions := 0
+			for _, event := range span.Events {
+				if event.Name == "exception" {
+					exceptions++
+				}
+			}
+			if exceptions != 1 {
+				t.Errorf("span has %d exception events, want 1", exceptions)
+			}
+		})
+	}
+}
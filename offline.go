@@ -0,0 +1,626 @@
+package judgeval
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultOfflineMaxFileSize = 10 << 20
+	offlineUploadBatchSize    = 512
+
+	offlineFileExt     = ".jsonl"
+	offlinePartialExt  = ".part"
+	offlineUploadedExt = ".uploaded"
+	offlineProgressExt = ".progress"
+)
+
+// OfflineParams switches the tracer to offline mode, in which spans are
+// written to JSONL files in Dir instead of being exported. Upload them later
+// with TracerFactory.UploadOfflineSpans.
+type OfflineParams struct {
+	Dir string
+	// MaxFileSize is the size in bytes after which a new file is started.
+	// Defaults to 10 MiB.
+	MaxFileSize *int
+}
+
+type OfflineUploadParams struct {
+	Dir string
+	// KeepFiles renames uploaded files with an .uploaded suffix instead of
+	// deleting them. Defaults to false.
+	KeepFiles *bool
+}
+
+// FileSpanExporter appends spans as JSON lines to rotated files. The file
+// being written has a .part suffix, which is dropped once it is rotated or
+// the exporter shuts down, so only complete files are picked up for upload.
+// Files are named after the writing process's PID and locked while written,
+// so several processes can share a directory.
+type FileSpanExporter struct {
+	dir         string
+	maxFileSize int
+
+	mu   sync.Mutex
+	file *os.File
+	size int
+}
+
+func NewFileSpanExporter(dir string, maxFileSize int) (*FileSpanExporter, error) {
+	if dir == "" {
+		return nil, errors.New("offline directory is required")
+	}
+	if maxFileSize <= 0 {
+		maxFileSize = defaultOfflineMaxFileSize
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create offline directory: %w", err)
+	}
+
+	// Files left partial by a process that has exited hold every line it
+	// managed to sync, so they are completed rather than discarded. Files
+	// still locked by a running writer are left alone.
+	partial, err := filepath.Glob(filepath.Join(dir, "*"+offlineFileExt+offlinePartialExt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offline files: %w", err)
+	}
+	for _, path := range partial {
+		if _, err := completeAbandonedOfflineFile(path); err != nil {
+			return nil, fmt.Errorf("failed to complete offline file %s: %w", path, err)
+		}
+	}
+
+	return &FileSpanExporter{dir: dir, maxFileSize: maxFileSize}, nil
+}
+
+func (e *FileSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	var buf []byte
+	for _, s := range spans {
+		line, err := json.Marshal(newOfflineSpan(s))
+		if err != nil {
+			logger.Warning("Failed to serialize span %s for offline storage: %v", s.Name(), err)
+			continue
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	return e.write(buf)
+}
+
+// writeTraceTags stores tags for a trace whose spans were written earlier,
+// to be sent once they are uploaded.
+func (e *FileSpanExporter) writeTraceTags(traceID string, tags []string) error {
+	line, err := json.Marshal(struct {
+		TraceTags offlineTraceTags `json:"trace_tags"`
+	}{offlineTraceTags{TraceID: traceID, Tags: tags}})
+	if err != nil {
+		return fmt.Errorf("failed to serialize trace tags: %w", err)
+	}
+	return e.write(append(line, '\n'))
+}
+
+func (e *FileSpanExporter) write(buf []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.file == nil {
+		name := fmt.Sprintf("spans-%s-%d%s%s", time.Now().UTC().Format("20060102T150405.000000000"), os.Getpid(), offlineFileExt, offlinePartialExt)
+		file, err := os.OpenFile(filepath.Join(e.dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open offline file: %w", err)
+		}
+		if err := lockOfflineFile(file); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to lock offline file: %w", err)
+		}
+		e.file, e.size = file, 0
+	}
+
+	n, err := e.file.Write(buf)
+	e.size += n
+	if err != nil {
+		return fmt.Errorf("failed to write offline spans: %w", err)
+	}
+	if err := e.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync offline file: %w", err)
+	}
+
+	if e.size >= e.maxFileSize {
+		return e.rotate()
+	}
+	return nil
+}
+
+func (e *FileSpanExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.rotate()
+}
+
+func (e *FileSpanExporter) rotate() error {
+	if e.file == nil {
+		return nil
+	}
+	path := e.file.Name()
+	err := e.file.Close()
+	e.file = nil
+	if err != nil {
+		return fmt.Errorf("failed to close offline file: %w", err)
+	}
+	if err := os.Rename(path, strings.TrimSuffix(path, offlinePartialExt)); err != nil {
+		return fmt.Errorf("failed to complete offline file: %w", err)
+	}
+	return nil
+}
+
+// UploadOfflineSpans exports the spans stored in params.Dir by an offline
+// tracer, marking each with judgment.offline_mode, then sends the trace tags
+// recorded with them and triggers root span rules for the uploaded traces.
+// Files are processed oldest first and removed once uploaded. Progress
+// through each file is recorded after every batch, so on failure the next
+// call resumes where this one stopped instead of uploading spans twice. It
+// returns the number of spans uploaded.
+func (f *TracerFactory) UploadOfflineSpans(ctx context.Context, params OfflineUploadParams) (int, error) {
+	if params.Dir == "" {
+		return 0, errors.New("offline directory is required")
+	}
+	if f.projectID == "" {
+		return 0, errors.New("project not resolved; cannot upload offline spans")
+	}
+
+	paths, err := filepath.Glob(filepath.Join(params.Dir, "*"+offlineFileExt))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list offline files: %w", err)
+	}
+	slices.Sort(paths)
+
+	judgmentExporter := NewJudgmentSpanExporter(ctx, (&BaseTracer{apiClient: f.client}).buildEndpoint(), f.client, f.projectID)
+	if _, ok := judgmentExporter.(*NoOpSpanExporter); ok {
+		return 0, errors.New("failed to create span exporter for offline upload")
+	}
+	rootSpanRules := NewRootSpanRulesProcessor(f.client)
+	exporter := &hookedSpanExporter{
+		SpanExporter: judgmentExporter,
+		afterExport:  []exportHook{rootSpanRules.onExport},
+	}
+	defer exporter.Shutdown(ctx)
+	tagger := newTraceTagger(f.client, f.projectID)
+
+	uploaded := 0
+	for _, path := range paths {
+		spans, tags, err := readOfflineFile(path)
+		if err != nil {
+			return uploaded, err
+		}
+
+		done := min(readOfflineProgress(path), len(spans))
+		for start := done; start < len(spans); start += offlineUploadBatchSize {
+			batch := spans[start:min(start+offlineUploadBatchSize, len(spans))]
+			for _, s := range batch {
+				rootSpanRules.OnEnd(s)
+			}
+			if err := exporter.ExportSpans(ctx, batch); err != nil {
+				return uploaded, fmt.Errorf("failed to upload offline spans from %s: %w", path, err)
+			}
+			uploaded += len(batch)
+			if err := writeOfflineProgress(path, start+len(batch)); err != nil {
+				return uploaded, err
+			}
+		}
+
+		for _, t := range tags {
+			if err := tagger.send(ctx, t.TraceID, t.Tags); err != nil {
+				return uploaded, fmt.Errorf("failed to upload offline trace tags from %s: %w", path, err)
+			}
+		}
+
+		if getBool(params.KeepFiles, false) {
+			err = os.Rename(path, path+offlineUploadedExt)
+		} else {
+			err = os.Remove(path)
+		}
+		if err != nil {
+			return uploaded, fmt.Errorf("failed to clean up uploaded offline file %s: %w", path, err)
+		}
+		if err := os.Remove(path + offlineProgressExt); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return uploaded, fmt.Errorf("failed to clean up offline progress file for %s: %w", path, err)
+		}
+		logger.Info("Uploaded %d offline spans from %s", len(spans)-done, path)
+	}
+	return uploaded, nil
+}
+
+// readOfflineProgress returns how many spans of the file at path were
+// uploaded by an earlier call, or 0 when unknown.
+func readOfflineProgress(path string) int {
+	data, err := os.ReadFile(path + offlineProgressExt)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			logger.Warning("Failed to read upload progress for %s, starting over: %v", path, err)
+		}
+		return 0
+	}
+	done, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || done < 0 {
+		logger.Warning("Ignoring malformed upload progress for %s", path)
+		return 0
+	}
+	return done
+}
+
+func writeOfflineProgress(path string, done int) error {
+	tmp := path + offlineProgressExt + offlinePartialExt
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(done)), 0o644); err != nil {
+		return fmt.Errorf("failed to record upload progress for %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path+offlineProgressExt); err != nil {
+		return fmt.Errorf("failed to record upload progress for %s: %w", path, err)
+	}
+	return nil
+}
+
+func readOfflineFile(path string) ([]sdktrace.ReadOnlySpan, []offlineTraceTags, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open offline file: %w", err)
+	}
+	defer file.Close()
+
+	var spans []sdktrace.ReadOnlySpan
+	var tags []offlineTraceTags
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record offlineRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			logger.Warning("Skipping malformed span at %s:%d: %v", path, line, err)
+			continue
+		}
+		if record.TraceTags != nil {
+			tags = append(tags, *record.TraceTags)
+			continue
+		}
+		span, err := record.span()
+		if err != nil {
+			logger.Warning("Skipping malformed span at %s:%d: %v", path, line, err)
+			continue
+		}
+		span.attributes = append(span.attributes, attribute.Bool(AttributeKeysJudgmentOfflineMode, true))
+		spans = append(spans, span)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read offline file %s: %w", path, err)
+	}
+	return spans, tags, nil
+}
+
+// offlineRecord is one line of an offline file: a span, or tags for a trace
+// whose spans appear earlier in the same or an older file.
+type offlineRecord struct {
+	offlineSpan
+	TraceTags *offlineTraceTags `json:"trace_tags,omitempty"`
+}
+
+type offlineTraceTags struct {
+	TraceID string   `json:"trace_id"`
+	Tags    []string `json:"tags"`
+}
+
+type offlineSpan struct {
+	Name              string             `json:"name"`
+	TraceID           string             `json:"trace_id"`
+	SpanID            string             `json:"span_id"`
+	TraceFlags        byte               `json:"trace_flags"`
+	TraceState        string             `json:"trace_state,omitempty"`
+	ParentSpanID      string             `json:"parent_span_id,omitempty"`
+	ParentRemote      bool               `json:"parent_remote,omitempty"`
+	Kind              int                `json:"kind"`
+	StartTime         time.Time          `json:"start_time"`
+	EndTime           time.Time          `json:"end_time"`
+	Attributes        []offlineAttribute `json:"attributes,omitempty"`
+	Events            []offlineEvent     `json:"events,omitempty"`
+	Links             []offlineLink      `json:"links,omitempty"`
+	StatusCode        uint32             `json:"status_code,omitempty"`
+	StatusDescription string             `json:"status_description,omitempty"`
+	DroppedAttributes int                `json:"dropped_attributes,omitempty"`
+	DroppedEvents     int                `json:"dropped_events,omitempty"`
+	DroppedLinks      int                `json:"dropped_links,omitempty"`
+	ChildSpanCount    int                `json:"child_span_count,omitempty"`
+	Resource          []offlineAttribute `json:"resource,omitempty"`
+	ResourceSchemaURL string             `json:"resource_schema_url,omitempty"`
+	ScopeName         string             `json:"scope_name,omitempty"`
+	ScopeVersion      string             `json:"scope_version,omitempty"`
+	ScopeSchemaURL    string             `json:"scope_schema_url,omitempty"`
+}
+
+type offlineEvent struct {
+	Name       string             `json:"name"`
+	Time       time.Time          `json:"time"`
+	Attributes []offlineAttribute `json:"attributes,omitempty"`
+}
+
+type offlineLink struct {
+	TraceID    string             `json:"trace_id"`
+	SpanID     string             `json:"span_id"`
+	TraceFlags byte               `json:"trace_flags"`
+	TraceState string             `json:"trace_state,omitempty"`
+	Remote     bool               `json:"remote,omitempty"`
+	Attributes []offlineAttribute `json:"attributes,omitempty"`
+}
+
+// offlineAttribute keeps the attribute type so values round-trip exactly,
+// including int64 values beyond float64 precision.
+type offlineAttribute struct {
+	Key   string          `json:"key"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+func newOfflineSpan(s sdktrace.ReadOnlySpan) offlineSpan {
+	spanContext := s.SpanContext()
+	record := offlineSpan{
+		Name:              s.Name(),
+		TraceID:           spanContext.TraceID().String(),
+		SpanID:            spanContext.SpanID().String(),
+		TraceFlags:        byte(spanContext.TraceFlags()),
+		TraceState:        spanContext.TraceState().String(),
+		Kind:              int(s.SpanKind()),
+		StartTime:         s.StartTime(),
+		EndTime:           s.EndTime(),
+		Attributes:        offlineAttributes(s.Attributes()),
+		StatusCode:        uint32(s.Status().Code),
+		StatusDescription: s.Status().Description,
+		DroppedAttributes: s.DroppedAttributes(),
+		DroppedEvents:     s.DroppedEvents(),
+		DroppedLinks:      s.DroppedLinks(),
+		ChildSpanCount:    s.ChildSpanCount(),
+		ScopeName:         s.InstrumentationScope().Name,
+		ScopeVersion:      s.InstrumentationScope().Version,
+		ScopeSchemaURL:    s.InstrumentationScope().SchemaURL,
+	}
+	if s.Parent().IsValid() {
+		record.ParentSpanID = s.Parent().SpanID().String()
+		record.ParentRemote = s.Parent().IsRemote()
+	}
+	if res := s.Resource(); res != nil {
+		record.Resource = offlineAttributes(res.Attributes())
+		record.ResourceSchemaURL = res.SchemaURL()
+	}
+	for _, event := range s.Events() {
+		record.Events = append(record.Events, offlineEvent{
+			Name:       event.Name,
+			Time:       event.Time,
+			Attributes: offlineAttributes(event.Attributes),
+		})
+	}
+	for _, link := range s.Links() {
+		record.Links = append(record.Links, offlineLink{
+			TraceID:    link.SpanContext.TraceID().String(),
+			SpanID:     link.SpanContext.SpanID().String(),
+			TraceFlags: byte(link.SpanContext.TraceFlags()),
+			TraceState: link.SpanContext.TraceState().String(),
+			Remote:     link.SpanContext.IsRemote(),
+			Attributes: offlineAttributes(link.Attributes),
+		})
+	}
+	return record
+}
+
+func (r offlineSpan) span() (*offlineReadOnlySpan, error) {
+	spanContext, err := offlineSpanContext(r.TraceID, r.SpanID, r.TraceFlags, r.TraceState, false)
+	if err != nil {
+		return nil, err
+	}
+
+	span := &offlineReadOnlySpan{
+		name:              r.Name,
+		spanContext:       spanContext,
+		spanKind:          trace.SpanKind(r.Kind),
+		startTime:         r.StartTime,
+		endTime:           r.EndTime,
+		status:            sdktrace.Status{Code: codes.Code(r.StatusCode), Description: r.StatusDescription},
+		droppedAttributes: r.DroppedAttributes,
+		droppedEvents:     r.DroppedEvents,
+		droppedLinks:      r.DroppedLinks,
+		childSpanCount:    r.ChildSpanCount,
+		scope: instrumentation.Scope{
+			Name:      r.ScopeName,
+			Version:   r.ScopeVersion,
+			SchemaURL: r.ScopeSchemaURL,
+		},
+	}
+	if span.attributes, err = parseOfflineAttributes(r.Attributes); err != nil {
+		return nil, err
+	}
+
+	if r.ParentSpanID != "" {
+		parentID, err := trace.SpanIDFromHex(r.ParentSpanID)
+		if err != nil {
+			return nil, err
+		}
+		span.parent = trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    spanContext.TraceID(),
+			SpanID:     parentID,
+			TraceFlags: spanContext.TraceFlags(),
+			Remote:     r.ParentRemote,
+		})
+	}
+
+	resourceAttrs, err := parseOfflineAttributes(r.Resource)
+	if err != nil {
+		return nil, err
+	}
+	span.resource = resource.NewWithAttributes(r.ResourceSchemaURL, resourceAttrs...)
+
+	for _, event := range r.Events {
+		attrs, err := parseOfflineAttributes(event.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		span.events = append(span.events, sdktrace.Event{Name: event.Name, Time: event.Time, Attributes: attrs})
+	}
+	for _, link := range r.Links {
+		linkContext, err := offlineSpanContext(link.TraceID, link.SpanID, link.TraceFlags, link.TraceState, link.Remote)
+		if err != nil {
+			return nil, err
+		}
+		attrs, err := parseOfflineAttributes(link.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		span.links = append(span.links, sdktrace.Link{SpanContext: linkContext, Attributes: attrs})
+	}
+	return span, nil
+}
+
+// offlineReadOnlySpan is a span read back from an offline file. The embedded
+// interface is always nil; it only satisfies the SDK's unexported method.
+type offlineReadOnlySpan struct {
+	sdktrace.ReadOnlySpan
+	name              string
+	spanContext       trace.SpanContext
+	parent            trace.SpanContext
+	spanKind          trace.SpanKind
+	startTime         time.Time
+	endTime           time.Time
+	attributes        []attribute.KeyValue
+	links             []sdktrace.Link
+	events            []sdktrace.Event
+	status            sdktrace.Status
+	scope             instrumentation.Scope
+	resource          *resource.Resource
+	droppedAttributes int
+	droppedLinks      int
+	droppedEvents     int
+	childSpanCount    int
+}
+
+func (s *offlineReadOnlySpan) Name() string                     { return s.name }
+func (s *offlineReadOnlySpan) SpanContext() trace.SpanContext   { return s.spanContext }
+func (s *offlineReadOnlySpan) Parent() trace.SpanContext        { return s.parent }
+func (s *offlineReadOnlySpan) SpanKind() trace.SpanKind         { return s.spanKind }
+func (s *offlineReadOnlySpan) StartTime() time.Time             { return s.startTime }
+func (s *offlineReadOnlySpan) EndTime() time.Time               { return s.endTime }
+func (s *offlineReadOnlySpan) Attributes() []attribute.KeyValue { return s.attributes }
+func (s *offlineReadOnlySpan) Links() []sdktrace.Link           { return s.links }
+func (s *offlineReadOnlySpan) Events() []sdktrace.Event         { return s.events }
+func (s *offlineReadOnlySpan) Status() sdktrace.Status          { return s.status }
+func (s *offlineReadOnlySpan) Resource() *resource.Resource     { return s.resource }
+func (s *offlineReadOnlySpan) DroppedAttributes() int           { return s.droppedAttributes }
+func (s *offlineReadOnlySpan) DroppedLinks() int                { return s.droppedLinks }
+func (s *offlineReadOnlySpan) DroppedEvents() int               { return s.droppedEvents }
+func (s *offlineReadOnlySpan) ChildSpanCount() int              { return s.childSpanCount }
+
+func (s *offlineReadOnlySpan) InstrumentationScope() instrumentation.Scope {
+	return s.scope
+}
+
+//nolint:staticcheck // Required by sdktrace.ReadOnlySpan.
+func (s *offlineReadOnlySpan) InstrumentationLibrary() instrumentation.Library {
+	return s.scope
+}
+
+func offlineSpanContext(traceIDHex, spanIDHex string, flags byte, state string, remote bool) (trace.SpanContext, error) {
+	traceID, err := trace.TraceIDFromHex(traceIDHex)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	spanID, err := trace.SpanIDFromHex(spanIDHex)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	traceState, err := trace.ParseTraceState(state)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.TraceFlags(flags),
+		TraceState: traceState,
+		Remote:     remote,
+	}), nil
+}
+
+func offlineAttributes(attrs []attribute.KeyValue) []offlineAttribute {
+	out := make([]offlineAttribute, 0, len(attrs))
+	for _, kv := range attrs {
+		value, err := json.Marshal(kv.Value.AsInterface())
+		if err != nil {
+			continue
+		}
+		out = append(out, offlineAttribute{Key: string(kv.Key), Type: kv.Value.Type().String(), Value: value})
+	}
+	return out
+}
+
+func parseOfflineAttributes(attrs []offlineAttribute) ([]attribute.KeyValue, error) {
+	out := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		kv, err := parseOfflineAttribute(a)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", a.Key, err)
+		}
+		out = append(out, kv)
+	}
+	return out, nil
+}
+
+func parseOfflineAttribute(a offlineAttribute) (attribute.KeyValue, error) {
+	switch a.Type {
+	case attribute.BOOL.String():
+		var v bool
+		err := json.Unmarshal(a.Value, &v)
+		return attribute.Bool(a.Key, v), err
+	case attribute.INT64.String():
+		var v int64
+		err := json.Unmarshal(a.Value, &v)
+		return attribute.Int64(a.Key, v), err
+	case attribute.FLOAT64.String():
+		var v float64
+		err := json.Unmarshal(a.Value, &v)
+		return attribute.Float64(a.Key, v), err
+	case attribute.STRING.String():
+		var v string
+		err := json.Unmarshal(a.Value, &v)
+		return attribute.String(a.Key, v), err
+	case attribute.BOOLSLICE.String():
+		var v []bool
+		err := json.Unmarshal(a.Value, &v)
+		return attribute.BoolSlice(a.Key, v), err
+	case attribute.INT64SLICE.String():
+		var v []int64
+		err := json.Unmarshal(a.Value, &v)
+		return attribute.Int64Slice(a.Key, v), err
+	case attribute.FLOAT64SLICE.String():
+		var v []float64
+		err := json.Unmarshal(a.Value, &v)
+		return attribute.Float64Slice(a.Key, v), err
+	case attribute.STRINGSLICE.String():
+		var v []string
+		err := json.Unmarshal(a.Value, &v)
+		return attribute.StringSlice(a.Key, v), err
+	}
+	return attribute.KeyValue{}, fmt.Errorf("unsupported type %q", a.Type)
+}
@@ -0,0 +1,49 @@
+//go:build !unix
+
+package judgeval
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// lockOfflineFile is a no-op where file locks are unavailable; abandoned
+// files are recognized by the writer's PID instead.
+func lockOfflineFile(file *os.File) error {
+	return nil
+}
+
+// completeAbandonedOfflineFile renames a partial file to its final name if
+// the process named in it is no longer running. A reused PID makes the file
+// look live, so it is only completed once that process exits too.
+func completeAbandonedOfflineFile(path string) (bool, error) {
+	pid, ok := offlineFilePID(path)
+	if !ok || pid == os.Getpid() {
+		return false, nil
+	}
+	// FindProcess only succeeds for running processes on Windows.
+	if process, err := os.FindProcess(pid); err == nil {
+		process.Release()
+		return false, nil
+	}
+	if err := os.Rename(path, strings.TrimSuffix(path, offlinePartialExt)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	return true, nil
+}
+
+// offlineFilePID returns the PID in a file name written by
+// FileSpanExporter.
+func offlineFilePID(path string) (int, bool) {
+	name := strings.TrimSuffix(filepath.Base(path), offlineFileExt+offlinePartialExt)
+	i := strings.LastIndexByte(name, '-')
+	if i < 0 {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(name[i+1:])
+	return pid, err == nil && pid > 0
+}
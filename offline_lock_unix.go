@@ -0,0 +1,42 @@
+//go:build unix
+
+package judgeval
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// lockOfflineFile takes an exclusive lock on a file being written. The lock
+// is released when the file is closed or the process exits.
+func lockOfflineFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
+
+// completeAbandonedOfflineFile renames a partial file to its final name if
+// no process holds its lock. It reports false for files still being
+// written.
+func completeAbandonedOfflineFile(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	if err := lockOfflineFile(file); err != nil {
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := os.Rename(path, strings.TrimSuffix(path, offlinePartialExt)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,266 @@
+package judgeval
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func offlineFiles(t *testing.T, dir, pattern string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	return paths
+}
+
+func TestFileSpanExporterRotates(t *testing.T) {
+	dir := t.TempDir()
+	exporter, err := NewFileSpanExporter(dir, 1)
+	if err != nil {
+		t.Fatalf("NewFileSpanExporter: %v", err)
+	}
+
+	for range 3 {
+		if err := exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{rootSpan(testTraceID(1))}); err != nil {
+			t.Fatalf("ExportSpans: %v", err)
+		}
+	}
+	if got := offlineFiles(t, dir, "*"+offlineFileExt); len(got) != 3 {
+		t.Errorf("got %d complete files, want one per write: %v", len(got), got)
+	}
+	if got := offlineFiles(t, dir, "*"+offlinePartialExt); len(got) != 0 {
+		t.Errorf("partial files left after rotation: %v", got)
+	}
+}
+
+func TestFileSpanExporterRecoversPartialFiles(t *testing.T) {
+	dir := t.TempDir()
+	live, err := NewFileSpanExporter(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("NewFileSpanExporter: %v", err)
+	}
+	if err := live.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{rootSpan(testTraceID(1))}); err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+
+	// A file named after a running process, as after PID reuse, but that no
+	// writer holds open.
+	abandoned := filepath.Join(dir, fmt.Sprintf("spans-20240101T000000.000000000-%d%s%s", os.Getpid(), offlineFileExt, offlinePartialExt))
+	if err := os.WriteFile(abandoned, []byte("{}\n"), 0o644); err != nil {
+		t.Fatalf("write abandoned file: %v", err)
+	}
+
+	if _, err := NewFileSpanExporter(dir, 1<<20); err != nil {
+		t.Fatalf("NewFileSpanExporter: %v", err)
+	}
+	if _, err := os.Stat(strings.TrimSuffix(abandoned, offlinePartialExt)); err != nil {
+		t.Errorf("abandoned file was not completed: %v", err)
+	}
+	if got := offlineFiles(t, dir, "*"+offlinePartialExt); len(got) != 1 {
+		t.Errorf("partial files = %v, want only the live writer's", got)
+	}
+
+	if err := live.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if got := offlineFiles(t, dir, "*"+offlineFileExt); len(got) != 2 {
+		t.Errorf("complete files = %v, want 2", got)
+	}
+}
+
+func TestOfflineRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	exporter, err := NewFileSpanExporter(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("NewFileSpanExporter: %v", err)
+	}
+	memory := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithSyncer(memory),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "offline-test"))),
+	)
+	tracer := provider.Tracer("offline", trace.WithInstrumentationVersion("1.2.3"))
+
+	linked := trace.NewSpanContext(trace.SpanContextConfig{TraceID: testTraceID(9), SpanID: trace.SpanID{9}, TraceFlags: trace.FlagsSampled})
+	ctx, root := tracer.Start(context.Background(), "root", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tracer.Start(ctx, "child", trace.WithLinks(trace.Link{SpanContext: linked, Attributes: []attribute.KeyValue{attribute.String("why", "retry")}}))
+	child.SetAttributes(
+		attribute.Bool("bool", true),
+		attribute.Int64("big", 1<<62+1),
+		attribute.Float64("float", 0.25),
+		attribute.String("string", "value"),
+		attribute.BoolSlice("bools", []bool{true, false}),
+		attribute.Int64Slice("ints", []int64{1, 2}),
+		attribute.Float64Slice("floats", []float64{0.5}),
+		attribute.StringSlice("strings", []string{"a", "b"}),
+	)
+	child.AddEvent("event", trace.WithAttributes(attribute.Int64("n", 3)))
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	root.End()
+
+	traceID := root.SpanContext().TraceID().String()
+	if err := exporter.writeTraceTags(traceID, []string{"nightly", "regression"}); err != nil {
+		t.Fatalf("writeTraceTags: %v", err)
+	}
+	want := memory.GetSpans()
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	paths := offlineFiles(t, dir, "*"+offlineFileExt)
+	if len(paths) != 1 {
+		t.Fatalf("offline files = %v, want 1", paths)
+	}
+	spans, tags, err := readOfflineFile(paths[0])
+	if err != nil {
+		t.Fatalf("readOfflineFile: %v", err)
+	}
+
+	if len(spans) != len(want) {
+		t.Fatalf("read %d spans, want %d", len(spans), len(want))
+	}
+	for i, got := range spans {
+		w := want[i]
+		wantAttrs := append(w.Attributes, attribute.Bool(AttributeKeysJudgmentOfflineMode, true))
+		checks := []struct {
+			field     string
+			got, want any
+		}{
+			{"name", got.Name(), w.Name},
+			{"span context", got.SpanContext(), w.SpanContext},
+			{"parent", got.Parent(), w.Parent},
+			{"kind", got.SpanKind(), w.SpanKind},
+			{"start", got.StartTime().UnixNano(), w.StartTime.UnixNano()},
+			{"end", got.EndTime().UnixNano(), w.EndTime.UnixNano()},
+			{"attributes", got.Attributes(), wantAttrs},
+			{"links", got.Links(), w.Links},
+			{"status", got.Status(), w.Status},
+			{"scope", got.InstrumentationScope(), w.InstrumentationScope},
+			{"resource", got.Resource().Attributes(), w.Resource.Attributes()},
+			{"child count", got.ChildSpanCount(), w.ChildSpanCount},
+		}
+		for _, c := range checks {
+			if !reflect.DeepEqual(c.got, c.want) {
+				t.Errorf("%s: %s = %v, want %v", w.Name, c.field, c.got, c.want)
+			}
+		}
+		if len(got.Events()) != len(w.Events) {
+			t.Errorf("%s: %d events, want %d", w.Name, len(got.Events()), len(w.Events))
+		}
+		for j, event := range got.Events() {
+			if event.Name != w.Events[j].Name || !event.Time.Equal(w.Events[j].Time) || !reflect.DeepEqual(event.Attributes, w.Events[j].Attributes) {
+				t.Errorf("%s: event %d = %+v, want %+v", w.Name, j, event, w.Events[j])
+			}
+		}
+	}
+
+	if len(tags) != 1 || tags[0].TraceID != traceID || !reflect.DeepEqual(tags[0].Tags, []string{"nightly", "regression"}) {
+		t.Errorf("tags = %+v, want one record for %s", tags, traceID)
+	}
+}
+
+// offlineUploadServer accepts OTLP exports, failing the export calls listed
+// in failExports, and records the trace tags it receives.
+type offlineUploadServer struct {
+	exports     atomic.Int32
+	failExports map[int32]bool
+	mu          sync.Mutex
+	tagged      []string
+}
+
+func newTestOfflineUpload(t *testing.T, failExports ...int32) (*TracerFactory, *offlineUploadServer) {
+	t.Helper()
+	upload := &offlineUploadServer{failExports: make(map[int32]bool)}
+	for _, n := range failExports {
+		upload.failExports[n] = true
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/otel/v1/traces":
+			if upload.failExports[upload.exports.Add(1)] {
+				http.Error(w, "rejected", http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/x-protobuf")
+		case strings.HasSuffix(r.URL.Path, "/tags"):
+			upload.mu.Lock()
+			upload.tagged = append(upload.tagged, r.URL.Path)
+			upload.mu.Unlock()
+			w.Write([]byte(`{}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return &TracerFactory{client: api.NewClient(server.URL, "key", "org"), projectID: "project"}, upload
+}
+
+func TestUploadOfflineSpansResumesFromProgress(t *testing.T) {
+	dir := t.TempDir()
+	exporter, err := NewFileSpanExporter(dir, 1<<30)
+	if err != nil {
+		t.Fatalf("NewFileSpanExporter: %v", err)
+	}
+	spans := make([]sdktrace.ReadOnlySpan, offlineUploadBatchSize+1)
+	for i := range spans {
+		spans[i] = rootSpan(testTraceID(byte(i%200 + 1)))
+	}
+	if err := exporter.ExportSpans(context.Background(), spans); err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+	if err := exporter.writeTraceTags(testTraceID(1).String(), []string{"offline"}); err != nil {
+		t.Fatalf("writeTraceTags: %v", err)
+	}
+	if err := exporter.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	factory, upload := newTestOfflineUpload(t, 2)
+	uploaded, err := factory.UploadOfflineSpans(context.Background(), OfflineUploadParams{Dir: dir})
+	if err == nil {
+		t.Fatal("UploadOfflineSpans succeeded, want the second batch to fail")
+	}
+	if uploaded != offlineUploadBatchSize {
+		t.Errorf("uploaded %d spans before the failure, want %d", uploaded, offlineUploadBatchSize)
+	}
+	if got := offlineFiles(t, dir, "*"+offlineProgressExt); len(got) != 1 {
+		t.Fatalf("progress files = %v, want 1", got)
+	}
+
+	uploaded, err = factory.UploadOfflineSpans(context.Background(), OfflineUploadParams{Dir: dir})
+	if err != nil {
+		t.Fatalf("UploadOfflineSpans: %v", err)
+	}
+	if uploaded != 1 {
+		t.Errorf("resumed upload sent %d spans, want the 1 remaining", uploaded)
+	}
+	if got := upload.exports.Load(); got != 3 {
+		t.Errorf("export calls = %d, want 3", got)
+	}
+	if got := upload.tagged; len(got) != 1 || !strings.Contains(got[0], testTraceID(1).String()) {
+		t.Errorf("tagged = %v, want the stored tags sent once", got)
+	}
+	if got := offlineFiles(t, dir, "*"); len(got) != 0 {
+		t.Errorf("files left after upload: %v", got)
+	}
+}
@@ -1,9 +1,12 @@
 package judgeval
 
+import "time"
+
 type clientConfig struct {
-	apiKey string
-	orgID  string
-	apiURL string
+	apiKey    string
+	orgID     string
+	apiURL    string
+	projectID string
 }
 
 type Option interface {
@@ -34,6 +37,14 @@ func WithAPIURL(url string) Option {
 	})
 }
 
+// WithProjectID skips resolving the project ID from the project name, which
+// requires network access.
+func WithProjectID(id string) Option {
+	return optionFunc(func(c *clientConfig) {
+		c.projectID = id
+	})
+}
+
 func Bool(v bool) *bool {
 	return &v
 }
@@ -70,3 +81,17 @@ func getFloat(ptr *float64, defaultVal float64) float64 {
 	}
 	return *ptr
 }
+
+func getInt(ptr *int, defaultVal int) int {
+	if ptr == nil {
+		return defaultVal
+	}
+	return *ptr
+}
+
+func getDuration(ptr *time.Duration, defaultVal time.Duration) time.Duration {
+	if ptr == nil {
+		return defaultVal
+	}
+	return *ptr
+}
@@ -2,13 +2,30 @@ package judgeval
 
 import (
 	"context"
+	"slices"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type JudgmentSpanProcessor struct {
 	delegate  sdktrace.SpanProcessor
 	lifecycle []sdktrace.SpanProcessor
+
+	updateInterval time.Duration
+	mu             sync.Mutex
+	open           map[trace.SpanID]*openSpan
+	stop           chan struct{}
+	done           chan struct{}
+	stopOnce       sync.Once
+}
+
+type openSpan struct {
+	span     sdktrace.ReadWriteSpan
+	updateID int64
 }
 
 func NewJudgmentSpanProcessor(
@@ -21,15 +38,55 @@ func NewJudgmentSpanProcessor(
 	}
 }
 
+// NewJudgmentSpanProcessorWithUpdates returns a processor that, every
+// interval, also hands the delegate a snapshot of each span that has been
+// open for at least interval. Snapshots and the final span carry an
+// increasing update ID so the backend can merge partial data as it arrives.
+func NewJudgmentSpanProcessorWithUpdates(
+	delegate sdktrace.SpanProcessor,
+	lifecycle []sdktrace.SpanProcessor,
+	interval time.Duration,
+) sdktrace.SpanProcessor {
+	if interval <= 0 {
+		return NewJudgmentSpanProcessor(delegate, lifecycle)
+	}
+	p := &JudgmentSpanProcessor{
+		delegate:       delegate,
+		lifecycle:      lifecycle,
+		updateInterval: interval,
+		open:           make(map[trace.SpanID]*openSpan),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	go p.exportUpdates()
+	return p
+}
+
 func (p *JudgmentSpanProcessor) ForceFlush(ctx context.Context) error {
 	return p.delegate.ForceFlush(ctx)
 }
 
 func (p *JudgmentSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	for _, processor := range p.lifecycle {
+		processor.OnEnd(s)
+	}
+	if p.updateInterval > 0 {
+		p.mu.Lock()
+		tracked, ok := p.open[s.SpanContext().SpanID()]
+		delete(p.open, s.SpanContext().SpanID())
+		p.mu.Unlock()
+		if ok && tracked.updateID > 0 {
+			s = withUpdateID(s, tracked.updateID+1, s.EndTime())
+		}
+	}
 	p.delegate.OnEnd(s)
 }
 
 func (p *JudgmentSpanProcessor) Shutdown(ctx context.Context) error {
+	if p.updateInterval > 0 {
+		p.stopOnce.Do(func() { close(p.stop) })
+		<-p.done
+	}
 	return p.delegate.Shutdown(ctx)
 }
 
@@ -37,9 +94,98 @@ func (p *JudgmentSpanProcessor) OnStart(parentContext context.Context, span sdkt
 	for _, processor := range p.lifecycle {
 		processor.OnStart(parentContext, span)
 	}
+	if p.updateInterval > 0 {
+		p.mu.Lock()
+		p.open[span.SpanContext().SpanID()] = &openSpan{span: span}
+		p.mu.Unlock()
+	}
 	p.delegate.OnStart(parentContext, span)
 }
 
+func (p *JudgmentSpanProcessor) exportUpdates() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.updateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case now := <-ticker.C:
+			for _, snapshot := range p.snapshotOpenSpans(now) {
+				p.delegate.OnEnd(snapshot)
+			}
+		}
+	}
+}
+
+func (p *JudgmentSpanProcessor) snapshotOpenSpans(now time.Time) []sdktrace.ReadOnlySpan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var snapshots []sdktrace.ReadOnlySpan
+	for _, tracked := range p.open {
+		if now.Sub(tracked.span.StartTime()) < p.updateInterval {
+			continue
+		}
+		tracked.updateID++
+		snapshots = append(snapshots, withUpdateID(tracked.span, tracked.updateID, now))
+	}
+	return snapshots
+}
+
+// spanUpdate is a point-in-time copy of a span's mutable data, tagged with
+// an update ID. Open spans are given the snapshot time as their end time.
+// Only fields that never change after the span starts are read through the
+// embedded span.
+type spanUpdate struct {
+	sdktrace.ReadOnlySpan
+	name              string
+	attributes        []attribute.KeyValue
+	links             []sdktrace.Link
+	events            []sdktrace.Event
+	status            sdktrace.Status
+	endTime           time.Time
+	droppedAttributes int
+	droppedLinks      int
+	droppedEvents     int
+	childSpanCount    int
+}
+
+func withUpdateID(s sdktrace.ReadOnlySpan, updateID int64, now time.Time) sdktrace.ReadOnlySpan {
+	endTime := s.EndTime()
+	if endTime.IsZero() {
+		endTime = now
+	}
+	// The SDK returns its own attribute slice, so append to a copy.
+	attributes := append(slices.Clip(s.Attributes()), attribute.Int64(AttributeKeysJudgmentUpdateID, updateID))
+	return &spanUpdate{
+		ReadOnlySpan:      s,
+		name:              s.Name(),
+		attributes:        attributes,
+		links:             s.Links(),
+		events:            s.Events(),
+		status:            s.Status(),
+		endTime:           endTime,
+		droppedAttributes: s.DroppedAttributes(),
+		droppedLinks:      s.DroppedLinks(),
+		droppedEvents:     s.DroppedEvents(),
+		childSpanCount:    s.ChildSpanCount(),
+	}
+}
+
+func (s *spanUpdate) Name() string                     { return s.name }
+func (s *spanUpdate) Attributes() []attribute.KeyValue { return s.attributes }
+func (s *spanUpdate) Links() []sdktrace.Link           { return s.links }
+func (s *spanUpdate) Events() []sdktrace.Event         { return s.events }
+func (s *spanUpdate) Status() sdktrace.Status          { return s.status }
+func (s *spanUpdate) EndTime() time.Time               { return s.endTime }
+func (s *spanUpdate) DroppedAttributes() int           { return s.droppedAttributes }
+func (s *spanUpdate) DroppedLinks() int                { return s.droppedLinks }
+func (s *spanUpdate) DroppedEvents() int               { return s.droppedEvents }
+func (s *spanUpdate) ChildSpanCount() int              { return s.childSpanCount }
+
 type NoOpSpanProcessor struct{}
 
 func NewNoOpSpanProcessor() sdktrace.SpanProcessor {
@@ -0,0 +1,93 @@
+package judgeval
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordingProcessor keeps every span it is handed in OnEnd.
+type recordingProcessor struct {
+	NoOpSpanProcessor
+	mu    sync.Mutex
+	ended []sdktrace.ReadOnlySpan
+}
+
+func (p *recordingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ended = append(p.ended, s)
+}
+
+func updateIDOf(t *testing.T, s sdktrace.ReadOnlySpan) int64 {
+	t.Helper()
+	for _, attr := range s.Attributes() {
+		if attr.Key == AttributeKeysJudgmentUpdateID {
+			return attr.Value.AsInt64()
+		}
+	}
+	t.Fatalf("span %s has no update ID", s.Name())
+	return 0
+}
+
+func TestJudgmentSpanProcessorUpdates(t *testing.T) {
+	delegate := &recordingProcessor{}
+	processor := NewJudgmentSpanProcessorWithUpdates(delegate, nil, time.Hour).(*JudgmentSpanProcessor)
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor))
+	defer provider.Shutdown(context.Background())
+	tracer := provider.Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "work")
+	span.SetAttributes(attribute.String(AttributeKeysJudgmentSpanKind, "tool"))
+
+	later := time.Now().Add(2 * time.Hour)
+	first := processor.snapshotOpenSpans(later)
+	if len(first) != 1 {
+		t.Fatalf("first snapshot has %d spans, want 1", len(first))
+	}
+
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	span.AddLink(trace.Link{SpanContext: child.SpanContext()})
+	span.SetName("renamed")
+
+	second := processor.snapshotOpenSpans(later)
+	span.End()
+
+	snapshot := first[0]
+	if got := updateIDOf(t, snapshot); got != 1 {
+		t.Errorf("first update ID = %d, want 1", got)
+	}
+	if snapshot.Name() != "work" || len(snapshot.Links()) != 0 || snapshot.ChildSpanCount() != 0 {
+		t.Errorf("first snapshot changed with the live span: name %q, %d links, %d children",
+			snapshot.Name(), len(snapshot.Links()), snapshot.ChildSpanCount())
+	}
+	if len(snapshot.Attributes()) != 2 || !snapshot.EndTime().Equal(later) {
+		t.Errorf("first snapshot has %d attributes and end time %v", len(snapshot.Attributes()), snapshot.EndTime())
+	}
+
+	if got := updateIDOf(t, second[0]); got != 2 {
+		t.Errorf("second update ID = %d, want 2", got)
+	}
+	if second[0].Name() != "renamed" || len(second[0].Links()) != 1 || second[0].ChildSpanCount() != 1 {
+		t.Errorf("second snapshot: name %q, %d links, %d children", second[0].Name(), len(second[0].Links()), second[0].ChildSpanCount())
+	}
+
+	delegate.mu.Lock()
+	defer delegate.mu.Unlock()
+	final := delegate.ended[len(delegate.ended)-1]
+	if final.Name() != "renamed" {
+		t.Fatalf("last span handed to the delegate is %q, want the ended span", final.Name())
+	}
+	if got := updateIDOf(t, final); got != 3 {
+		t.Errorf("final update ID = %d, want 3", got)
+	}
+	if len(span.(sdktrace.ReadOnlySpan).Attributes()) != 1 {
+		t.Error("update IDs leaked into the live span's attributes")
+	}
+}
@@ -6,18 +6,29 @@ import (
 	"maps"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/JudgmentLabs/judgeval-go/env"
 	"github.com/JudgmentLabs/judgeval-go/internal/api"
 	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+	"github.com/JudgmentLabs/judgeval-go/logger"
 )
 
+const defaultPromptScorerCacheTTL = 5 * time.Minute
+
 type PromptScorerFactory struct {
 	client      *api.Client
 	projectName string
 	projectID   string
 	isTrace     bool
 	cache       sync.Map
+	cacheTTL    atomic.Int64
+}
+
+type promptScorerCacheEntry struct {
+	scorer    *PromptScorer
+	expiresAt time.Time
 }
 
 type PromptScorer struct {
@@ -27,16 +38,35 @@ type PromptScorer struct {
 	options     map[string]float64
 	model       string
 	description string
+	resultType  ScorerResultType
+	scoreRange  ScoreRange
 	isTrace     bool
 }
 
-func (f *PromptScorerFactory) Get(ctx context.Context, name string) (*PromptScorer, error) {
-	cacheKey := f.buildCacheKey(name)
+func newPromptScorerFactory(client *api.Client, projectName string, projectID string, isTrace bool) *PromptScorerFactory {
+	f := &PromptScorerFactory{
+		client:      client,
+		projectName: projectName,
+		projectID:   projectID,
+		isTrace:     isTrace,
+	}
+	f.cacheTTL.Store(int64(defaultPromptScorerCacheTTL))
+	return f
+}
 
-	if cached, ok := f.cache.Load(cacheKey); ok {
-		return cached.(*PromptScorer), nil
+// Get returns the named prompt scorer, serving it from the cache when a
+// fresh entry exists. Each call returns an independent copy, so setters on
+// the result never affect other callers.
+func (f *PromptScorerFactory) Get(ctx context.Context, name string) (*PromptScorer, error) {
+	if scorer, ok := f.load(name); ok {
+		return scorer, nil
 	}
+	return f.Refresh(ctx, name)
+}
 
+// Refresh fetches the named prompt scorer from the platform, replacing any
+// cached entry, and returns an independent copy of it.
+func (f *PromptScorerFactory) Refresh(ctx context.Context, name string) (*PromptScorer, error) {
 	names := name
 	isTrace := strconv.FormatBool(f.isTrace)
 	resp, err := f.client.GetProjectsScorers(f.projectID, &names, &isTrace)
@@ -45,7 +75,8 @@ func (f *PromptScorerFactory) Get(ctx context.Context, name string) (*PromptScor
 	}
 
 	if len(resp.Scorers) == 0 {
-		return nil, fmt.Errorf("failed to fetch prompt scorer '%s': not found", name)
+		f.Invalidate(name)
+		return nil, &ScorerNotFoundError{Name: name, ScoreType: f.scoreType()}
 	}
 
 	scorerModel := resp.Scorers[0]
@@ -62,14 +93,64 @@ func (f *PromptScorerFactory) Get(ctx context.Context, name string) (*PromptScor
 		return nil, fmt.Errorf("scorer with name %s is a %s, not a %s", name, actualType, expectedType)
 	}
 
+	scorer := f.newPromptScorer(name, scorerModel)
+	f.store(scorer)
+	return scorer.clone(), nil
+}
+
+// Exists reports whether a scorer with the given name exists in the project.
+// Fresh cache entries are reported without a round trip.
+func (f *PromptScorerFactory) Exists(ctx context.Context, name string) (bool, error) {
+	if _, ok := f.load(name); ok {
+		return true, nil
+	}
+
+	resp, err := f.client.GetProjectsScorersByNameExists(f.projectID, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if prompt scorer '%s' exists: %w", name, err)
+	}
+	return resp.Exists, nil
+}
+
+// Invalidate drops the cached entry for the named prompt scorer so the next
+// Get fetches it from the platform.
+func (f *PromptScorerFactory) Invalidate(name string) {
+	f.cache.Delete(f.buildCacheKey(name))
+}
+
+// SetCacheTTL sets how long fetched prompt scorers are served from the cache.
+// A non-positive TTL disables caching.
+func (f *PromptScorerFactory) SetCacheTTL(ttl time.Duration) {
+	f.cacheTTL.Store(int64(ttl))
+	if ttl <= 0 {
+		f.cache.Clear()
+	}
+}
+
+func (f *PromptScorerFactory) newPromptScorer(name string, scorerModel models.PromptScorer) *PromptScorer {
 	options := make(map[string]float64)
 	for k, v := range scorerModel.Options {
-		if floatVal, ok := v.(float64); ok {
-			options[k] = floatVal
+		if score, ok := optionScore(v); ok {
+			options[k] = score
+		} else {
+			logger.Warning("Ignoring non-numeric option %q on prompt scorer %s", k, name)
 		}
 	}
 
-	threshold := 0.5
+	resultType := ScorerResultTypeNumeric
+	if v, ok := scorerModel.AdditionalProperties["result_type"].(string); ok && ScorerResultType(v).valid() {
+		resultType = ScorerResultType(v)
+	}
+
+	scoreRange := DefaultScoreRange
+	lower, hasLower := optionScore(scorerModel.AdditionalProperties["minimum_score_range"])
+	upper, hasUpper := optionScore(scorerModel.AdditionalProperties["maximum_score_range"])
+	if hasLower && hasUpper && upper > lower {
+		scoreRange = ScoreRange{Min: lower, Max: upper}
+		scoreRange.LowerIsBetter, _ = scorerModel.AdditionalProperties["lower_is_better"].(bool)
+	}
+
+	threshold := scoreRange.midpoint()
 	if scorerModel.Threshold != 0 {
 		threshold = scorerModel.Threshold
 	}
@@ -79,23 +160,65 @@ func (f *PromptScorerFactory) Get(ctx context.Context, name string) (*PromptScor
 		modelName = scorerModel.Model
 	}
 
-	description := ""
-	if scorerModel.Description != "" {
-		description = scorerModel.Description
-	}
-
-	scorer := &PromptScorer{
+	return &PromptScorer{
 		name:        name,
 		prompt:      scorerModel.Prompt,
 		threshold:   threshold,
 		options:     options,
 		model:       modelName,
-		description: description,
+		description: scorerModel.Description,
+		resultType:  resultType,
+		scoreRange:  scoreRange,
 		isTrace:     f.isTrace,
 	}
+}
 
-	f.cache.Store(cacheKey, scorer)
-	return scorer, nil
+func optionScore(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case bool:
+		return boolScore(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func (f *PromptScorerFactory) load(name string) (*PromptScorer, bool) {
+	cacheKey := f.buildCacheKey(name)
+	cached, ok := f.cache.Load(cacheKey)
+	if !ok {
+		return nil, false
+	}
+
+	entry := cached.(*promptScorerCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		f.cache.CompareAndDelete(cacheKey, entry)
+		return nil, false
+	}
+	return entry.scorer.clone(), true
+}
+
+func (f *PromptScorerFactory) store(scorer *PromptScorer) {
+	ttl := time.Duration(f.cacheTTL.Load())
+	if ttl <= 0 {
+		return
+	}
+	f.cache.Store(f.buildCacheKey(scorer.name), &promptScorerCacheEntry{
+		scorer:    scorer.clone(),
+		expiresAt: time.Now().Add(ttl),
+	})
+}
+
+func (f *PromptScorerFactory) scoreType() APIScorerType {
+	if f.isTrace {
+		return APIScorerTypeTracePromptScorer
+	}
+	return APIScorerTypePromptScorer
 }
 
 func (f *PromptScorerFactory) buildCacheKey(name string) string {
@@ -128,6 +251,16 @@ func (s *PromptScorer) GetDescription() string {
 	return s.description
 }
 
+func (s *PromptScorer) GetResultType() ScorerResultType {
+	return s.resultType
+}
+
+// ScoreForLabel returns the score mapped to a category label by the
+// scorer's options. Labels match case-insensitively.
+func (s *PromptScorer) ScoreForLabel(label string) (float64, bool) {
+	return scoreForLabel(s.options, label)
+}
+
 func (s *PromptScorer) SetThreshold(threshold float64) {
 	s.threshold = threshold
 }
@@ -149,10 +282,37 @@ func (s *PromptScorer) SetDescription(description string) {
 	s.description = description
 }
 
+func (s *PromptScorer) SetResultType(resultType ScorerResultType) {
+	s.resultType = resultType
+}
+
+func (s *PromptScorer) GetScoreRange() ScoreRange {
+	return s.scoreRange
+}
+
+func (s *PromptScorer) SetScoreRange(scoreRange ScoreRange) error {
+	if err := scoreRange.validate(); err != nil {
+		return err
+	}
+	s.scoreRange = scoreRange
+	return nil
+}
+
 func (s *PromptScorer) AppendToPrompt(addition string) {
 	s.prompt = s.prompt + addition
 }
 
+func (s *PromptScorer) IsTrace() bool {
+	return s.isTrace
+}
+
+func (s *PromptScorer) clone() *PromptScorer {
+	c := *s
+	c.options = make(map[string]float64, len(s.options))
+	maps.Copy(c.options, s.options)
+	return &c
+}
+
 func (s *PromptScorer) GetScorerConfig() *models.ScorerConfig {
 	scoreType := APIScorerTypePromptScorer.String()
 	if s.isTrace {
@@ -172,11 +332,18 @@ func (s *PromptScorer) GetScorerConfig() *models.ScorerConfig {
 	if s.description != "" {
 		kwargs["description"] = s.description
 	}
-
-	return &models.ScorerConfig{
-		ScoreType: scoreType,
-		Threshold: s.threshold,
-		Name:      s.name,
-		Kwargs:    kwargs,
+	s.scoreRange.toKwargs(kwargs)
+
+	config := &models.ScorerConfig{
+		ScoreType:  scoreType,
+		Threshold:  s.threshold,
+		Name:       s.name,
+		Kwargs:     kwargs,
+		ResultType: s.resultType.String(),
+	}
+	// As for apiScorer, a zero threshold would be dropped by omitempty.
+	if config.Threshold == 0 {
+		config.AdditionalProperties = map[string]any{"threshold": 0.0}
 	}
+	return config
 }
@@ -9,6 +9,12 @@ import (
 
 type customerIDContextKey struct{}
 type sessionIDContextKey struct{}
+type agentContextKey struct{}
+
+type agentContext struct {
+	id       string
+	parentID string
+}
 
 func contextWithCustomerID(ctx context.Context, customerID string) context.Context {
 	return context.WithValue(ctx, customerIDContextKey{}, customerID)
@@ -18,6 +24,15 @@ func contextWithSessionID(ctx context.Context, sessionID string) context.Context
 	return context.WithValue(ctx, sessionIDContextKey{}, sessionID)
 }
 
+func contextWithAgent(ctx context.Context, agent *agentContext) context.Context {
+	return context.WithValue(ctx, agentContextKey{}, agent)
+}
+
+func agentFromContext(ctx context.Context) (*agentContext, bool) {
+	value, ok := ctx.Value(agentContextKey{}).(*agentContext)
+	return value, ok
+}
+
 func customerIDFromContext(ctx context.Context) (string, bool) {
 	value, ok := ctx.Value(customerIDContextKey{}).(string)
 	return value, ok
@@ -50,9 +65,30 @@ func (p *SessionIDProcessor) OnStart(parentContext context.Context, span sdktrac
 	}
 }
 
+// AgentProcessor stamps spans started under an agent with its ID and its
+// parent agent's ID.
+type AgentProcessor struct{}
+
+func (p *AgentProcessor) ForceFlush(ctx context.Context) error { return nil }
+func (p *AgentProcessor) OnEnd(s sdktrace.ReadOnlySpan)        {}
+func (p *AgentProcessor) Shutdown(ctx context.Context) error   { return nil }
+func (p *AgentProcessor) OnStart(parentContext context.Context, span sdktrace.ReadWriteSpan) {
+	agent, ok := agentFromContext(parentContext)
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(attribute.String(AttributeKeysJudgmentAgentID, agent.id))
+	if agent.parentID != "" {
+		span.SetAttributes(attribute.String(AttributeKeysJudgmentParentAgentID, agent.parentID))
+	}
+}
+
 func lifecycleSpanProcessors() []sdktrace.SpanProcessor {
 	return []sdktrace.SpanProcessor{
 		&CustomerIDProcessor{},
 		&SessionIDProcessor{},
+		&AgentProcessor{},
+		&CostProcessor{},
 	}
 }
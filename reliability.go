@@ -0,0 +1,450 @@
+package judgeval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultReliabilityRepeats = 5
+
+type RepeatParams struct {
+	Examples []*Example
+	// Scorer may be local or server-hosted; server-hosted scorers are run on
+	// the platform once per repeat.
+	Scorer BaseScorer
+	// Repeats is how many times each example is scored. Defaults to 5.
+	Repeats *int
+	// RemoteTimeout bounds how long each server-hosted run is awaited.
+	// Defaults to 5 minutes.
+	RemoteTimeout *time.Duration
+}
+
+// ExampleRepeatResult summarizes repeated scoring of one example. Runs that
+// returned an error are counted in Errors and left out of the statistics.
+type ExampleRepeatResult struct {
+	Example  *Example
+	Results  []*ScorerResult
+	Mean     float64
+	Variance float64
+	// FlipRate is the fraction of consecutive runs whose pass/fail verdict
+	// differs from the previous run.
+	FlipRate float64
+	Errors   int
+}
+
+type RepeatResult struct {
+	ScorerName   string
+	Repeats      int
+	Results      []*ExampleRepeatResult
+	MeanVariance float64
+	MeanFlipRate float64
+	// Unstable counts examples whose verdict flipped at least once.
+	Unstable int
+}
+
+// Repeat scores each example several times with the same scorer to measure
+// how consistent a judge is before trusting it as a gate.
+func (e *Evaluation) Repeat(ctx context.Context, params RepeatParams) (*RepeatResult, error) {
+	if params.Scorer == nil {
+		return nil, errors.New("scorer is required")
+	}
+	repeats := getInt(params.Repeats, defaultReliabilityRepeats)
+	if repeats < 2 {
+		return nil, fmt.Errorf("repeats must be at least 2, got %d", repeats)
+	}
+
+	remote := e.remoteScorerRunner(params.RemoteTimeout)
+	result := &RepeatResult{
+		ScorerName: params.Scorer.GetName(),
+		Repeats:    repeats,
+		Results:    make([]*ExampleRepeatResult, 0, len(params.Examples)),
+	}
+
+	for _, example := range params.Examples {
+		exampleResult := &ExampleRepeatResult{
+			Example: example,
+			Results: make([]*ScorerResult, 0, repeats),
+		}
+
+		var scores []float64
+		var verdicts []bool
+		for range repeats {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			scorerResult, err := scoreWith(ctx, remote, params.Scorer, example)
+			if err != nil {
+				exampleResult.Errors++
+				exampleResult.Results = append(exampleResult.Results, &ScorerResult{
+					Name:  params.Scorer.GetName(),
+					Error: err.Error(),
+				})
+				continue
+			}
+			exampleResult.Results = append(exampleResult.Results, scorerResult)
+			scores = append(scores, scorerResult.Score)
+			verdicts = append(verdicts, scorerResult.Success)
+		}
+
+		exampleResult.Mean, exampleResult.Variance = meanVariance(scores)
+		exampleResult.FlipRate = flipRate(verdicts)
+		if exampleResult.FlipRate > 0 {
+			result.Unstable++
+		}
+
+		result.MeanVariance += exampleResult.Variance
+		result.MeanFlipRate += exampleResult.FlipRate
+		result.Results = append(result.Results, exampleResult)
+	}
+
+	if n := len(result.Results); n > 0 {
+		result.MeanVariance /= float64(n)
+		result.MeanFlipRate /= float64(n)
+	}
+	return result, nil
+}
+
+// AgreementParams configures Agreement. Judge and Reference may be local or
+// server-hosted scorers; server-hosted ones are run on the platform one
+// example at a time. To run a platform prompt scorer's prompt locally
+// instead, wrap it with LocalScorersFactory.JudgeFromPromptScorer.
+type AgreementParams struct {
+	Examples []*Example
+	Judge    BaseScorer
+	// Reference is the second judge to compare against. Either Reference or
+	// LabelField must be set.
+	Reference BaseScorer
+	// LabelField names an Example property holding a human label: a bool, a
+	// number on the judge's ScoreRange, or a category label matching the
+	// judge's options. Examples whose label is missing or cannot be scored
+	// by the judge are skipped.
+	LabelField *string
+	// RemoteTimeout bounds how long each server-hosted run is awaited.
+	// Defaults to 5 minutes.
+	RemoteTimeout *time.Duration
+}
+
+// AgreementPair is one example's judge result alongside the reference
+// rating it was compared with.
+type AgreementPair struct {
+	Example   *Example
+	Judge     *ScorerResult
+	Reference *ScorerResult
+	Agree     bool
+}
+
+type AgreementResult struct {
+	JudgeName     string
+	ReferenceName string
+	Pairs         []*AgreementPair
+	// Skipped counts examples where either side errored or had no usable
+	// label.
+	Skipped int
+	// Agreement is the fraction of pairs with the same verdict.
+	Agreement float64
+	// CohensKappa is chance-corrected agreement on verdicts: labels when
+	// every pair has one on both sides, pass/fail otherwise. It is NaN when
+	// there are no pairs.
+	CohensKappa float64
+	// Spearman is the rank correlation of numeric scores. It is NaN when
+	// there are fewer than two pairs or either side is constant.
+	Spearman float64
+}
+
+// Agreement compares a judge with a second judge or with human labels stored
+// on each example.
+func (e *Evaluation) Agreement(ctx context.Context, params AgreementParams) (*AgreementResult, error) {
+	if params.Judge == nil {
+		return nil, errors.New("judge scorer is required")
+	}
+	if params.Reference == nil && params.LabelField == nil {
+		return nil, errors.New("either a reference scorer or a label field is required")
+	}
+
+	remote := e.remoteScorerRunner(params.RemoteTimeout)
+	result := &AgreementResult{JudgeName: params.Judge.GetName()}
+	if params.Reference != nil {
+		result.ReferenceName = params.Reference.GetName()
+	} else {
+		result.ReferenceName = *params.LabelField
+	}
+
+	var judgeScores, referenceScores []float64
+	for _, example := range params.Examples {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		judgeResult, err := scoreWith(ctx, remote, params.Judge, example)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+
+		var reference *ScorerResult
+		if params.Reference != nil {
+			if referenceResult, err := scoreWith(ctx, remote, params.Reference, example); err == nil {
+				reference = referenceResult
+			}
+		} else {
+			reference = humanRating(example, *params.LabelField, params.Judge, judgeResult.Threshold)
+		}
+		if reference == nil {
+			result.Skipped++
+			continue
+		}
+
+		result.Pairs = append(result.Pairs, &AgreementPair{
+			Example:   example,
+			Judge:     judgeResult,
+			Reference: reference,
+		})
+		judgeScores = append(judgeScores, judgeResult.Score)
+		referenceScores = append(referenceScores, reference.Score)
+	}
+
+	// Verdicts are compared as labels only when every pair has one on both
+	// sides, so a run never mixes labels with pass/fail.
+	byLabel := len(result.Pairs) > 0
+	for _, pair := range result.Pairs {
+		if pair.Judge.Label == "" || pair.Reference.Label == "" {
+			byLabel = false
+			break
+		}
+	}
+
+	judgeVerdicts := make([]string, len(result.Pairs))
+	referenceVerdicts := make([]string, len(result.Pairs))
+	agreed := 0
+	for i, pair := range result.Pairs {
+		judgeVerdicts[i] = verdictOf(pair.Judge, byLabel)
+		referenceVerdicts[i] = verdictOf(pair.Reference, byLabel)
+		pair.Agree = judgeVerdicts[i] == referenceVerdicts[i]
+		if pair.Agree {
+			agreed++
+		}
+	}
+
+	if len(result.Pairs) > 0 {
+		result.Agreement = float64(agreed) / float64(len(result.Pairs))
+	}
+	result.CohensKappa = cohensKappa(judgeVerdicts, referenceVerdicts)
+	result.Spearman = spearman(judgeScores, referenceScores)
+	return result, nil
+}
+
+func (e *Evaluation) remoteScorerRunner(timeout *time.Duration) *remoteScorerRunner {
+	return &remoteScorerRunner{
+		client:    e.client,
+		projectID: e.projectID,
+		timeout:   getDuration(timeout, defaultRemoteScorerTimeout),
+	}
+}
+
+// scoreWith scores one example with a local scorer directly, or with a
+// server-hosted scorer through remote. A server-side scoring error is
+// returned as an error.
+func scoreWith(ctx context.Context, remote *remoteScorerRunner, scorer BaseScorer, example *Example) (*ScorerResult, error) {
+	if local, ok := scorer.(LocalScorer); ok {
+		return local.Score(ctx, example)
+	}
+	results, err := remote.score(ctx, example, []BaseScorer{scorer})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run server-hosted scorer '%s': %w", scorer.GetName(), err)
+	}
+	result, ok := results[scorer.GetName()]
+	if !ok {
+		return nil, fmt.Errorf("no result returned by the server for scorer '%s'", scorer.GetName())
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("scorer '%s' failed on the server: %s", scorer.GetName(), result.Error)
+	}
+	return result, nil
+}
+
+// humanRating reads a human label from an example property, judging numeric
+// and boolean labels with the same range and threshold as the judge. A
+// category label is only usable when the judge maps it to a score; otherwise
+// it returns nil rather than guessing a verdict.
+func humanRating(example *Example, field string, judge BaseScorer, threshold float64) *ScorerResult {
+	value, ok := example.properties[field]
+	if !ok || value == nil {
+		return nil
+	}
+
+	scoreRange := scoreRangeOf(judge)
+	rated := func(score float64) *ScorerResult {
+		return &ScorerResult{
+			Name:            field,
+			Score:           score,
+			Threshold:       threshold,
+			Success:         scoreRange.Passes(score, threshold),
+			NormalizedScore: scoreRange.Normalize(score),
+		}
+	}
+
+	switch v := value.(type) {
+	case bool:
+		r := rated(boolScore(v))
+		r.Success = v
+		return r
+	case int:
+		return rated(float64(v))
+	case int64:
+		return rated(float64(v))
+	case float32:
+		return rated(float64(v))
+	case float64:
+		return rated(v)
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			r := rated(boolScore(b))
+			r.Success = b
+			return r
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return rated(f)
+		}
+		labeled, ok := judge.(interface {
+			ScoreForLabel(string) (float64, bool)
+		})
+		if !ok {
+			return nil
+		}
+		score, ok := labeled.ScoreForLabel(v)
+		if !ok {
+			return nil
+		}
+		r := rated(score)
+		r.Label = v
+		return r
+	}
+	return nil
+}
+
+func verdictOf(result *ScorerResult, byLabel bool) string {
+	if byLabel {
+		return strings.ToLower(result.Label)
+	}
+	if result.Success {
+		return "pass"
+	}
+	return "fail"
+}
+
+func meanVariance(values []float64) (mean, variance float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, variance / float64(len(values))
+}
+
+func flipRate(verdicts []bool) float64 {
+	if len(verdicts) < 2 {
+		return 0
+	}
+	flips := 0
+	for i := 1; i < len(verdicts); i++ {
+		if verdicts[i] != verdicts[i-1] {
+			flips++
+		}
+	}
+	return float64(flips) / float64(len(verdicts)-1)
+}
+
+// cohensKappa returns Cohen's kappa for two raters' categorical verdicts.
+// When both raters always give the same single category it returns 1.
+func cohensKappa(a, b []string) float64 {
+	if len(a) == 0 {
+		return math.NaN()
+	}
+
+	n := float64(len(a))
+	countsA := make(map[string]float64)
+	countsB := make(map[string]float64)
+	observed := 0.0
+	for i := range a {
+		countsA[a[i]]++
+		countsB[b[i]]++
+		if a[i] == b[i] {
+			observed++
+		}
+	}
+	observed /= n
+
+	expected := 0.0
+	for category, count := range countsA {
+		expected += (count / n) * (countsB[category] / n)
+	}
+	if expected == 1 {
+		return 1
+	}
+	return (observed - expected) / (1 - expected)
+}
+
+// spearman returns Spearman's rank correlation, using average ranks for
+// ties.
+func spearman(x, y []float64) float64 {
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	return pearson(ranks(x), ranks(y))
+}
+
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		switch {
+		case values[i] < values[j]:
+			return -1
+		case values[i] > values[j]:
+			return 1
+		}
+		return 0
+	})
+
+	result := make([]float64, len(values))
+	for start := 0; start < len(order); {
+		end := start
+		for end+1 < len(order) && values[order[end+1]] == values[order[start]] {
+			end++
+		}
+		rank := float64(start+end)/2 + 1
+		for k := start; k <= end; k++ {
+			result[order[k]] = rank
+		}
+		start = end + 1
+	}
+	return result
+}
+
+func pearson(x, y []float64) float64 {
+	meanX, varX := meanVariance(x)
+	meanY, varY := meanVariance(y)
+	if varX == 0 || varY == 0 {
+		return math.NaN()
+	}
+
+	covariance := 0.0
+	for i := range x {
+		covariance += (x[i] - meanX) * (y[i] - meanY)
+	}
+	covariance /= float64(len(x))
+	return covariance / math.Sqrt(varX*varY)
+}
@@ -0,0 +1,270 @@
+package judgeval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+)
+
+func TestCohensKappa(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want float64
+	}{
+		{name: "perfect agreement", a: []string{"pass", "fail", "pass", "fail"}, b: []string{"pass", "fail", "pass", "fail"}, want: 1},
+		{name: "single shared category", a: []string{"pass", "pass"}, b: []string{"pass", "pass"}, want: 1},
+		{name: "chance agreement", a: []string{"pass", "pass", "fail", "fail"}, b: []string{"pass", "fail", "pass", "fail"}, want: 0},
+		{name: "partial agreement", a: []string{"pass", "pass", "pass", "fail"}, b: []string{"pass", "pass", "fail", "fail"}, want: 0.5},
+		{name: "complete disagreement", a: []string{"pass", "fail"}, b: []string{"fail", "pass"}, want: -1},
+		{name: "labels", a: []string{"good", "bad", "ugly"}, b: []string{"good", "bad", "ugly"}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cohensKappa(tt.a, tt.b); math.Abs(got-tt.want) > metricTolerance {
+				t.Errorf("cohensKappa(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+
+	if got := cohensKappa(nil, nil); !math.IsNaN(got) {
+		t.Errorf("cohensKappa(nil, nil) = %v, want NaN", got)
+	}
+}
+
+func TestSpearman(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []float64
+		want float64
+	}{
+		{name: "monotonic", x: []float64{1, 2, 3}, y: []float64{10, 20, 30}, want: 1},
+		{name: "monotonic but not linear", x: []float64{1, 2, 3, 4}, y: []float64{1, 4, 9, 100}, want: 1},
+		{name: "reversed", x: []float64{1, 2, 3}, y: []float64{3, 2, 1}, want: -1},
+		{name: "ties use average ranks", x: []float64{1, 2, 2, 3}, y: []float64{1, 2, 3, 4}, want: 4.5 / math.Sqrt(22.5)},
+		{name: "one pair", x: []float64{1}, y: []float64{1}, want: math.NaN()},
+		{name: "constant side", x: []float64{1, 1, 1}, y: []float64{1, 2, 3}, want: math.NaN()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := spearman(tt.x, tt.y)
+			if math.IsNaN(tt.want) {
+				if !math.IsNaN(got) {
+					t.Errorf("spearman(%v, %v) = %v, want NaN", tt.x, tt.y, got)
+				}
+				return
+			}
+			if math.Abs(got-tt.want) > metricTolerance {
+				t.Errorf("spearman(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlipRate(t *testing.T) {
+	tests := []struct {
+		verdicts []bool
+		want     float64
+	}{
+		{verdicts: nil, want: 0},
+		{verdicts: []bool{true}, want: 0},
+		{verdicts: []bool{true, true, true}, want: 0},
+		{verdicts: []bool{true, false, true}, want: 1},
+		{verdicts: []bool{true, true, false, false, false}, want: 0.25},
+	}
+
+	for _, tt := range tests {
+		if got := flipRate(tt.verdicts); math.Abs(got-tt.want) > metricTolerance {
+			t.Errorf("flipRate(%v) = %v, want %v", tt.verdicts, got, tt.want)
+		}
+	}
+}
+
+func TestHumanRating(t *testing.T) {
+	judge := (&LocalScorersFactory{}).ExactMatch(LocalScorerParams{})
+	tests := []struct {
+		name        string
+		label       any
+		wantNil     bool
+		wantScore   float64
+		wantSuccess bool
+	}{
+		{name: "bool", label: true, wantScore: 1, wantSuccess: true},
+		{name: "bool string", label: "false", wantScore: 0, wantSuccess: false},
+		{name: "number", label: 0.8, wantScore: 0.8, wantSuccess: true},
+		{name: "number string", label: "0.2", wantScore: 0.2, wantSuccess: false},
+		{name: "int", label: 1, wantScore: 1, wantSuccess: true},
+		{name: "label the judge cannot score", label: "approved", wantNil: true},
+		{name: "missing", label: nil, wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			example := NewExample(ExampleParams{"human": tt.label})
+			got := humanRating(example, "human", judge, 0.5)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("humanRating() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("humanRating() = nil, want a rating")
+			}
+			if got.Score != tt.wantScore || got.Success != tt.wantSuccess {
+				t.Errorf("humanRating() = (score %v, success %t), want (score %v, success %t)", got.Score, got.Success, tt.wantScore, tt.wantSuccess)
+			}
+		})
+	}
+}
+
+// propertyScorer scores each example with the number stored in its property
+// of the same name, and errors when the property is missing.
+func propertyScorer(name string) *stubScorer {
+	return &stubScorer{name: name, score: func(example *Example) (*ScorerResult, error) {
+		score, ok := example.properties[name].(float64)
+		if !ok {
+			return nil, errors.New("no score")
+		}
+		return &ScorerResult{Name: name, Score: score, Threshold: 0.5, Success: score >= 0.5}, nil
+	}}
+}
+
+func TestRepeat(t *testing.T) {
+	scores := []float64{0.9, 0.2, -1, 0.8}
+	calls := 0
+	scorer := &stubScorer{name: "flaky", score: func(*Example) (*ScorerResult, error) {
+		score := scores[calls%len(scores)]
+		calls++
+		if score < 0 {
+			return nil, errors.New("boom")
+		}
+		return &ScorerResult{Name: "flaky", Score: score, Threshold: 0.5, Success: score >= 0.5}, nil
+	}}
+
+	result, err := (&Evaluation{}).Repeat(context.Background(), RepeatParams{
+		Examples: []*Example{NewExample(ExampleParams{})},
+		Scorer:   scorer,
+		Repeats:  Int(4),
+	})
+	if err != nil {
+		t.Fatalf("Repeat: %v", err)
+	}
+
+	got := result.Results[0]
+	if got.Errors != 1 || len(got.Results) != 4 {
+		t.Errorf("errors = %d, results = %d, want 1 and 4", got.Errors, len(got.Results))
+	}
+	if math.Abs(got.Mean-1.9/3) > metricTolerance || math.Abs(got.Variance-0.09555555555555557) > metricTolerance {
+		t.Errorf("mean, variance = %v, %v", got.Mean, got.Variance)
+	}
+	if got.FlipRate != 1 || result.Unstable != 1 {
+		t.Errorf("flip rate = %v, unstable = %d, want 1 and 1", got.FlipRate, result.Unstable)
+	}
+
+	if _, err := (&Evaluation{}).Repeat(context.Background(), RepeatParams{Scorer: scorer, Repeats: Int(1)}); err == nil {
+		t.Error("expected an error for fewer than 2 repeats")
+	}
+}
+
+func TestAgreementWithReference(t *testing.T) {
+	examples := []*Example{
+		NewExample(ExampleParams{"judge": 0.9, "reference": 0.7}),
+		NewExample(ExampleParams{"judge": 0.1, "reference": 0.3}),
+		NewExample(ExampleParams{"judge": 0.8, "reference": 0.2}),
+		NewExample(ExampleParams{"reference": 0.5}),
+	}
+
+	result, err := (&Evaluation{}).Agreement(context.Background(), AgreementParams{
+		Examples:  examples,
+		Judge:     propertyScorer("judge"),
+		Reference: propertyScorer("reference"),
+	})
+	if err != nil {
+		t.Fatalf("Agreement: %v", err)
+	}
+
+	if len(result.Pairs) != 3 || result.Skipped != 1 {
+		t.Errorf("pairs = %d, skipped = %d, want 3 and 1", len(result.Pairs), result.Skipped)
+	}
+	if math.Abs(result.Agreement-2.0/3) > metricTolerance {
+		t.Errorf("agreement = %v, want 2/3", result.Agreement)
+	}
+	if math.Abs(result.CohensKappa-0.4) > metricTolerance {
+		t.Errorf("kappa = %v, want 0.4", result.CohensKappa)
+	}
+	if math.Abs(result.Spearman-0.5) > metricTolerance {
+		t.Errorf("spearman = %v, want 0.5", result.Spearman)
+	}
+}
+
+func TestAgreementJudgeFromPromptScorer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"choices": []any{map[string]any{"message": map[string]string{"role": "assistant", "content": `{"choice": "polite"}`}}},
+		})
+	}))
+	defer server.Close()
+
+	prompt := &PromptScorer{
+		name:       "tone",
+		prompt:     "Is {{actual_output}} polite?",
+		threshold:  0.5,
+		options:    map[string]float64{"polite": 1, "rude": 0},
+		model:      "model",
+		resultType: ScorerResultTypeCategorical,
+		scoreRange: DefaultScoreRange,
+	}
+	judge, err := (&LocalScorersFactory{}).JudgeFromPromptScorer(prompt, JudgeBackend{BaseURL: String(server.URL)})
+	if err != nil {
+		t.Fatalf("JudgeFromPromptScorer: %v", err)
+	}
+
+	result, err := (&Evaluation{}).Agreement(context.Background(), AgreementParams{
+		Examples: []*Example{
+			NewExample(ExampleParams{"actual_output": "thanks", "human": "polite"}),
+			NewExample(ExampleParams{"actual_output": "go away", "human": "rude"}),
+		},
+		Judge:      judge,
+		LabelField: String("human"),
+	})
+	if err != nil {
+		t.Fatalf("Agreement: %v", err)
+	}
+
+	if len(result.Pairs) != 2 || result.Agreement != 0.5 {
+		t.Errorf("pairs = %d, agreement = %v, want 2 and 0.5", len(result.Pairs), result.Agreement)
+	}
+	if result.Pairs[1].Judge.Label != "polite" || result.Pairs[1].Reference.Label != "rude" {
+		t.Errorf("labels = %q vs %q, want polite vs rude", result.Pairs[1].Judge.Label, result.Pairs[1].Reference.Label)
+	}
+}
+
+func TestAgreementServerHostedJudge(t *testing.T) {
+	runner, _ := newTestRemoteScorerRunner(t, time.Minute, func(int) []models.ExperimentScorer {
+		return []models.ExperimentScorer{{Name: "tone", Score: 1, Threshold: 0.5}}
+	})
+	evaluation := &Evaluation{client: runner.client, projectID: runner.projectID}
+
+	judge := &PromptScorer{name: "tone", threshold: 0.5, resultType: ScorerResultTypeNumeric, scoreRange: DefaultScoreRange}
+	result, err := evaluation.Agreement(context.Background(), AgreementParams{
+		Examples:   []*Example{NewExample(ExampleParams{"human": true})},
+		Judge:      judge,
+		LabelField: String("human"),
+	})
+	if err != nil {
+		t.Fatalf("Agreement: %v", err)
+	}
+	if len(result.Pairs) != 1 || !result.Pairs[0].Agree || !result.Pairs[0].Judge.Success {
+		t.Errorf("result = %+v, want one agreeing pair", result)
+	}
+}
@@ -0,0 +1,113 @@
+package judgeval
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api"
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+	"github.com/JudgmentLabs/judgeval-go/logger"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const rootSpanRulesPendingTTL = 5 * time.Minute
+
+// RootSpanRulesProcessor triggers the platform's root span rules for every
+// trace whose root span has ended. Root spans are held until the exporter
+// reports them exported, then triggered in one request per exported batch,
+// so the rules never run before the trace has reached the platform. Root
+// spans whose export failed, or that were not exported within pendingTTL,
+// are dropped without triggering the rules.
+type RootSpanRulesProcessor struct {
+	apiClient  *api.Client
+	pendingTTL time.Duration
+
+	mu      sync.Mutex
+	pending map[trace.SpanID]pendingRootSpan
+}
+
+// pendingRootSpan keeps the root span's end time so that onExport can tell
+// the final span apart from an in-progress snapshot exported with the same
+// span ID.
+type pendingRootSpan struct {
+	info     models.TraceInfo
+	endTime  time.Time
+	heldFrom time.Time
+}
+
+func NewRootSpanRulesProcessor(apiClient *api.Client) *RootSpanRulesProcessor {
+	return &RootSpanRulesProcessor{
+		apiClient:  apiClient,
+		pendingTTL: rootSpanRulesPendingTTL,
+		pending:    make(map[trace.SpanID]pendingRootSpan),
+	}
+}
+
+func (p *RootSpanRulesProcessor) ForceFlush(ctx context.Context) error { return nil }
+func (p *RootSpanRulesProcessor) Shutdown(ctx context.Context) error   { return nil }
+
+func (p *RootSpanRulesProcessor) OnStart(parentContext context.Context, span sdktrace.ReadWriteSpan) {
+}
+
+func (p *RootSpanRulesProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	spanContext := s.SpanContext()
+	if s.Parent().IsValid() || !spanContext.IsSampled() {
+		return
+	}
+
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending[spanContext.SpanID()] = pendingRootSpan{
+		info: models.TraceInfo{
+			TraceId: spanContext.TraceID().String(),
+			SpanId:  spanContext.SpanID().String(),
+		},
+		endTime:  s.EndTime(),
+		heldFrom: now,
+	}
+
+	// Root spans in a batch the span processor dropped never reach the
+	// exporter, so they are only ever removed here.
+	expired := 0
+	for spanID, root := range p.pending {
+		if now.Sub(root.heldFrom) >= p.pendingTTL {
+			delete(p.pending, spanID)
+			expired++
+		}
+	}
+	if expired > 0 {
+		logger.Warning("Dropping root span rules for %d traces that were not exported within %s", expired, p.pendingTTL)
+	}
+}
+
+// onExport triggers the rules for the ended root spans in an exported batch,
+// and forgets the root spans of a batch that failed to export.
+func (p *RootSpanRulesProcessor) onExport(ctx context.Context, spans []sdktrace.ReadOnlySpan, err error) {
+	var traces []models.TraceInfo
+
+	p.mu.Lock()
+	for _, s := range spans {
+		spanID := s.SpanContext().SpanID()
+		if root, ok := p.pending[spanID]; ok && s.EndTime().Equal(root.endTime) {
+			traces = append(traces, root.info)
+			delete(p.pending, spanID)
+		}
+	}
+	p.mu.Unlock()
+
+	if len(traces) == 0 {
+		return
+	}
+	if err != nil {
+		logger.Warning("Not triggering root span rules for %d traces whose export failed", len(traces))
+		return
+	}
+	if _, err := p.apiClient.PostOtelTriggerRootSpanRules(&models.TriggerRootSpanRulesRequest{Traces: traces}); err != nil {
+		logger.Error("Failed to trigger root span rules for %d traces: %v", len(traces), err)
+		return
+	}
+	logger.Debug("Triggered root span rules for %d traces", len(traces))
+}
@@ -0,0 +1,107 @@
+package judgeval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api"
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeSpanExporter fails every export with err.
+type fakeSpanExporter struct {
+	err error
+}
+
+func (e *fakeSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	return e.err
+}
+
+func (e *fakeSpanExporter) Shutdown(ctx context.Context) error { return nil }
+
+func newTestRootSpanRules(t *testing.T) (*RootSpanRulesProcessor, *fakeSpanExporter, func() []string) {
+	t.Helper()
+	var (
+		mu        sync.Mutex
+		triggered []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req models.TriggerRootSpanRulesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode trigger request: %v", err)
+		}
+		mu.Lock()
+		for _, info := range req.Traces {
+			triggered = append(triggered, info.TraceId)
+		}
+		mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	processor := NewRootSpanRulesProcessor(api.NewClient(server.URL, "key", "org"))
+	exporter := &fakeSpanExporter{}
+	return processor, exporter, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), triggered...)
+	}
+}
+
+func rootSpan(traceID trace.TraceID) sdktrace.ReadOnlySpan {
+	return tracetest.SpanStub{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{traceID[0]},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		EndTime: time.Unix(1, 0),
+	}.Snapshot()
+}
+
+func TestRootSpanRulesProcessor(t *testing.T) {
+	processor, fake, triggered := newTestRootSpanRules(t)
+	exporter := &hookedSpanExporter{SpanExporter: fake, afterExport: []exportHook{processor.onExport}}
+	ctx := context.Background()
+
+	exported, failed, dropped := rootSpan(testTraceID(1)), rootSpan(testTraceID(2)), rootSpan(testTraceID(3))
+
+	processor.OnEnd(exported)
+	if err := exporter.ExportSpans(ctx, []sdktrace.ReadOnlySpan{exported}); err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+	if got := triggered(); len(got) != 1 || got[0] != testTraceID(1).String() {
+		t.Errorf("triggered = %v, want the exported trace", got)
+	}
+
+	fake.err = errors.New("export failed")
+	processor.OnEnd(failed)
+	if err := exporter.ExportSpans(ctx, []sdktrace.ReadOnlySpan{failed}); err == nil {
+		t.Fatal("ExportSpans succeeded, want the exporter's error")
+	}
+	if got := triggered(); len(got) != 1 {
+		t.Errorf("triggered = %v, want nothing for the failed batch", got)
+	}
+
+	// A batch dropped by the span processor never reaches the exporter, so
+	// its root span is only removed once it expires.
+	processor.pendingTTL = 10 * time.Millisecond
+	processor.OnEnd(dropped)
+	time.Sleep(20 * time.Millisecond)
+	processor.OnEnd(rootSpan(testTraceID(4)))
+
+	processor.mu.Lock()
+	defer processor.mu.Unlock()
+	if len(processor.pending) != 1 {
+		t.Errorf("pending = %v, want only the latest root span", processor.pending)
+	}
+}
@@ -0,0 +1,121 @@
+package judgeval
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SamplingParams configures which traces are recorded and exported. Spans
+// that are not sampled are skipped by AsyncEvaluate and AsyncTraceEvaluate.
+type SamplingParams struct {
+	// Ratio is the fraction of traces kept when no rule matches. Defaults
+	// to 1.
+	Ratio *float64
+	// ParentBased makes spans follow their parent's sampling decision when no
+	// rule matches. Defaults to true.
+	ParentBased *bool
+	// Rules are checked in order for every span, before the parent's
+	// decision, and the first match decides. A rule that keeps a span under
+	// an unsampled parent yields a partial trace rather than none.
+	Rules []SamplingRule
+}
+
+// SamplingRule matches spans by name, customer ID and session ID; empty
+// fields match anything. SpanName is a path.Match pattern, so "agent.*"
+// matches every span whose name starts with "agent.". A span matches
+// CustomerIDs or SessionIDs when the ID set with SetCustomerID or
+// SetSessionID is one of them.
+type SamplingRule struct {
+	SpanName    string
+	CustomerIDs []string
+	SessionIDs  []string
+	// Ratio is the fraction of matching traces kept.
+	Ratio float64
+}
+
+type judgmentSampler struct {
+	ratio       sdktrace.Sampler
+	parentBased bool
+	rules       []samplingRule
+}
+
+type samplingRule struct {
+	SamplingRule
+	sampler sdktrace.Sampler
+}
+
+func newSampler(params *SamplingParams) (sdktrace.Sampler, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	ratio := getFloat(params.Ratio, 1)
+	if ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("sampling ratio must be between 0 and 1, got %v", ratio)
+	}
+
+	s := &judgmentSampler{
+		ratio:       sdktrace.TraceIDRatioBased(ratio),
+		parentBased: getBool(params.ParentBased, true),
+	}
+	for i, rule := range params.Rules {
+		if rule.Ratio < 0 || rule.Ratio > 1 {
+			return nil, fmt.Errorf("sampling rule %d: ratio must be between 0 and 1, got %v", i, rule.Ratio)
+		}
+		if _, err := path.Match(rule.SpanName, ""); err != nil {
+			return nil, fmt.Errorf("sampling rule %d: invalid span name pattern %q: %w", i, rule.SpanName, err)
+		}
+		s.rules = append(s.rules, samplingRule{SamplingRule: rule, sampler: sdktrace.TraceIDRatioBased(rule.Ratio)})
+	}
+	return s, nil
+}
+
+func (s *judgmentSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, rule := range s.rules {
+		if rule.matches(p) {
+			return rule.sampler.ShouldSample(p)
+		}
+	}
+
+	parent := trace.SpanContextFromContext(p.ParentContext)
+	if s.parentBased && parent.IsValid() {
+		decision := sdktrace.Drop
+		if parent.IsSampled() {
+			decision = sdktrace.RecordAndSample
+		}
+		return sdktrace.SamplingResult{Decision: decision, Tracestate: parent.TraceState()}
+	}
+	return s.ratio.ShouldSample(p)
+}
+
+func (s *judgmentSampler) Description() string {
+	rules := make([]string, 0, len(s.rules))
+	for _, rule := range s.rules {
+		rules = append(rules, fmt.Sprintf("{name=%q,customers=%q,sessions=%q,%s}", rule.SpanName, rule.CustomerIDs, rule.SessionIDs, rule.sampler.Description()))
+	}
+	return fmt.Sprintf("JudgmentSampler{ratio=%s,parentBased=%t,rules=[%s]}", s.ratio.Description(), s.parentBased, strings.Join(rules, ","))
+}
+
+func (r samplingRule) matches(p sdktrace.SamplingParameters) bool {
+	if r.SpanName != "" {
+		if ok, _ := path.Match(r.SpanName, p.Name); !ok {
+			return false
+		}
+	}
+	if len(r.CustomerIDs) > 0 {
+		if customerID, ok := customerIDFromContext(p.ParentContext); !ok || !slices.Contains(r.CustomerIDs, customerID) {
+			return false
+		}
+	}
+	if len(r.SessionIDs) > 0 {
+		if sessionID, ok := sessionIDFromContext(p.ParentContext); !ok || !slices.Contains(r.SessionIDs, sessionID) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package judgeval
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSampler(t *testing.T) {
+	if s, err := newSampler(nil); s != nil || err != nil {
+		t.Errorf("newSampler(nil) = (%v, %v), want (nil, nil)", s, err)
+	}
+
+	invalid := []struct {
+		name   string
+		params SamplingParams
+	}{
+		{name: "ratio above 1", params: SamplingParams{Ratio: Float(1.5)}},
+		{name: "negative ratio", params: SamplingParams{Ratio: Float(-0.1)}},
+		{name: "rule ratio", params: SamplingParams{Rules: []SamplingRule{{SpanName: "a", Ratio: 2}}}},
+		{name: "rule pattern", params: SamplingParams{Rules: []SamplingRule{{SpanName: "[", Ratio: 1}}}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newSampler(&tt.params); err == nil {
+				t.Error("newSampler() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestJudgmentSamplerShouldSample(t *testing.T) {
+	traceID := trace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	parent := func(sampled bool) context.Context {
+		flags := trace.TraceFlags(0)
+		if sampled {
+			flags = trace.FlagsSampled
+		}
+		return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{1},
+			TraceFlags: flags,
+		}))
+	}
+
+	tests := []struct {
+		name     string
+		params   SamplingParams
+		ctx      context.Context
+		spanName string
+		want     sdktrace.SamplingDecision
+	}{
+		{name: "default keeps everything", ctx: context.Background(), spanName: "root", want: sdktrace.RecordAndSample},
+		{name: "zero ratio drops roots", params: SamplingParams{Ratio: Float(0)}, ctx: context.Background(), spanName: "root", want: sdktrace.Drop},
+		{name: "follows sampled parent", params: SamplingParams{Ratio: Float(0)}, ctx: parent(true), spanName: "child", want: sdktrace.RecordAndSample},
+		{name: "follows unsampled parent", ctx: parent(false), spanName: "child", want: sdktrace.Drop},
+		{name: "ignores parent when not parent based", params: SamplingParams{ParentBased: Bool(false)}, ctx: parent(false), spanName: "child", want: sdktrace.RecordAndSample},
+		{
+			name:     "rule overrides parent",
+			params:   SamplingParams{Rules: []SamplingRule{{SpanName: "agent.*", Ratio: 1}}},
+			ctx:      parent(false),
+			spanName: "agent.plan",
+			want:     sdktrace.RecordAndSample,
+		},
+		{
+			name:     "non-matching rule falls through",
+			params:   SamplingParams{Ratio: Float(0), Rules: []SamplingRule{{SpanName: "agent.*", Ratio: 1}}},
+			ctx:      context.Background(),
+			spanName: "tool.search",
+			want:     sdktrace.Drop,
+		},
+		{
+			name: "first matching rule wins",
+			params: SamplingParams{Rules: []SamplingRule{
+				{SpanName: "agent.*", Ratio: 0},
+				{SpanName: "agent.plan", Ratio: 1},
+			}},
+			ctx:      context.Background(),
+			spanName: "agent.plan",
+			want:     sdktrace.Drop,
+		},
+		{
+			name:     "customer rule",
+			params:   SamplingParams{Ratio: Float(0), Rules: []SamplingRule{{CustomerIDs: []string{"acme"}, Ratio: 1}}},
+			ctx:      contextWithCustomerID(context.Background(), "acme"),
+			spanName: "root",
+			want:     sdktrace.RecordAndSample,
+		},
+		{
+			name:     "customer rule needs a customer",
+			params:   SamplingParams{Ratio: Float(0), Rules: []SamplingRule{{CustomerIDs: []string{"acme"}, Ratio: 1}}},
+			ctx:      contextWithCustomerID(context.Background(), "other"),
+			spanName: "root",
+			want:     sdktrace.Drop,
+		},
+		{
+			name:     "session and name must both match",
+			params:   SamplingParams{Ratio: Float(0), Rules: []SamplingRule{{SpanName: "chat", SessionIDs: []string{"s1"}, Ratio: 1}}},
+			ctx:      contextWithSessionID(context.Background(), "s1"),
+			spanName: "other",
+			want:     sdktrace.Drop,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler, err := newSampler(&tt.params)
+			if err != nil {
+				t.Fatalf("newSampler() error = %v", err)
+			}
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: tt.ctx,
+				TraceID:       traceID,
+				Name:          tt.spanName,
+			})
+			if result.Decision != tt.want {
+				t.Errorf("ShouldSample(%q) = %v, want %v", tt.spanName, result.Decision, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package judgeval
+
+import "fmt"
+
+// ScoreRange declares the scale a scorer reports on, such as 1-5 for a Likert
+// judge, and which end of it is better. Thresholds are always expressed on
+// the declared scale.
+type ScoreRange struct {
+	Min float64 `yaml:"min"`
+	Max float64 `yaml:"max"`
+	// LowerIsBetter flips pass/fail so that scores at or below the threshold
+	// pass, for toxicity-style scorers.
+	LowerIsBetter bool `yaml:"lower_is_better"`
+}
+
+// DefaultScoreRange is the 0-1, higher-is-better scale scorers use unless
+// they declare otherwise.
+var DefaultScoreRange = ScoreRange{Min: 0, Max: 1}
+
+// Passes reports whether score meets threshold. It is the single pass/fail
+// rule applied to local and server-hosted results alike.
+func (r ScoreRange) Passes(score, threshold float64) bool {
+	if r.LowerIsBetter {
+		return score <= threshold
+	}
+	return score >= threshold
+}
+
+// Normalize maps score onto 0-1 where 1 is always best, clamping values that
+// fall outside the range.
+func (r ScoreRange) Normalize(score float64) float64 {
+	n := (score - r.Min) / (r.Max - r.Min)
+	n = min(max(n, 0), 1)
+	if r.LowerIsBetter {
+		return 1 - n
+	}
+	return n
+}
+
+// Contains reports whether score lies within the range, inclusive.
+func (r ScoreRange) Contains(score float64) bool {
+	return score >= r.Min && score <= r.Max
+}
+
+// best returns the best score on the scale, which strict mode requires.
+func (r ScoreRange) best() float64 {
+	if r.LowerIsBetter {
+		return r.Min
+	}
+	return r.Max
+}
+
+// midpoint is the default threshold for scorers with a declared range.
+func (r ScoreRange) midpoint() float64 {
+	return r.Min + (r.Max-r.Min)/2
+}
+
+func (r ScoreRange) validate() error {
+	if r.Max <= r.Min {
+		return fmt.Errorf("score range maximum %v must be greater than minimum %v", r.Max, r.Min)
+	}
+	return nil
+}
+
+func (r ScoreRange) isDefault() bool {
+	return r == DefaultScoreRange
+}
+
+func (r ScoreRange) toKwargs(kwargs map[string]any) {
+	if r.isDefault() {
+		return
+	}
+	kwargs["minimum_score_range"] = r.Min
+	kwargs["maximum_score_range"] = r.Max
+	kwargs["lower_is_better"] = r.LowerIsBetter
+}
+
+// scoreRangeOf returns the range a scorer declares, or DefaultScoreRange for
+// scorers that do not declare one.
+func scoreRangeOf(scorer BaseScorer) ScoreRange {
+	if ranged, ok := scorer.(interface{ GetScoreRange() ScoreRange }); ok {
+		return ranged.GetScoreRange()
+	}
+	return DefaultScoreRange
+}
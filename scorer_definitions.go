@@ -0,0 +1,241 @@
+package judgeval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ScorerDefinitionType string
+
+const (
+	ScorerDefinitionTypeBuiltIn     ScorerDefinitionType = "builtin"
+	ScorerDefinitionTypePrompt      ScorerDefinitionType = "prompt"
+	ScorerDefinitionTypeTracePrompt ScorerDefinitionType = "trace_prompt"
+	ScorerDefinitionTypeCustom      ScorerDefinitionType = "custom"
+)
+
+// ScorerDefinition describes one scorer in a YAML or JSON definitions file.
+// Which fields apply depends on Type: builtin scorers use ScoreType, prompt
+// scorers use Prompt and Options, and custom scorers use ClassName.
+type ScorerDefinition struct {
+	Type           ScorerDefinitionType `yaml:"type"`
+	Name           string               `yaml:"name"`
+	ScoreType      string               `yaml:"score_type"`
+	Threshold      *float64             `yaml:"threshold"`
+	Model          *string              `yaml:"model"`
+	StrictMode     *bool                `yaml:"strict_mode"`
+	Prompt         *string              `yaml:"prompt"`
+	Description    *string              `yaml:"description"`
+	ClassName      string               `yaml:"class_name"`
+	Options        map[string]float64   `yaml:"options"`
+	ResultType     *ScorerResultType    `yaml:"result_type"`
+	Range          *ScoreRange          `yaml:"range"`
+	RequiredParams []string             `yaml:"required_params"`
+	Kwargs         map[string]any       `yaml:"kwargs"`
+}
+
+type scorerDefinitionsFile struct {
+	Scorers []ScorerDefinition `yaml:"scorers"`
+}
+
+type ScorerLoadParams struct {
+	// Path is read from FS when it is set, and from the local filesystem
+	// otherwise. Both YAML and JSON are accepted.
+	Path string
+	FS   fs.FS
+}
+
+// ParseScorerDefinitions parses a YAML or JSON document with a top-level
+// "scorers" list and validates each entry.
+func ParseScorerDefinitions(data []byte) ([]ScorerDefinition, error) {
+	var file scorerDefinitionsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse scorer definitions: %w", err)
+	}
+
+	var errs []error
+	for i, def := range file.Scorers {
+		if err := def.validate(i); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return file.Scorers, nil
+}
+
+func (d *ScorerDefinition) validate(index int) error {
+	prefix := fmt.Sprintf("scorer %d", index)
+	if d.Name != "" {
+		prefix = fmt.Sprintf("scorer %d (%s)", index, d.Name)
+	}
+
+	if d.ResultType != nil && !d.ResultType.valid() {
+		return fmt.Errorf("%s: unknown result_type '%s'", prefix, *d.ResultType)
+	}
+	if d.Range != nil {
+		if err := d.Range.validate(); err != nil {
+			return fmt.Errorf("%s: %w", prefix, err)
+		}
+	}
+
+	switch d.Type {
+	case ScorerDefinitionTypeBuiltIn:
+		if d.ScoreType == "" {
+			return fmt.Errorf("%s: %s is required", prefix, "score_type")
+		}
+		// Score types without defaults would otherwise be sent with no
+		// required params, which usually means a typo in score_type.
+		if _, known := builtInRequiredParams[APIScorerType(d.ScoreType)]; !known && d.RequiredParams == nil {
+			return fmt.Errorf("%s: unknown score_type '%s'; set required_params to use a score type this SDK has no defaults for", prefix, d.ScoreType)
+		}
+	case ScorerDefinitionTypePrompt, ScorerDefinitionTypeTracePrompt:
+		if d.Name == "" {
+			return fmt.Errorf("%s: %s is required", prefix, "name")
+		}
+	case ScorerDefinitionTypeCustom:
+		if d.Name == "" {
+			return fmt.Errorf("%s: %s is required", prefix, "name")
+		}
+		if d.ClassName == "" {
+			return fmt.Errorf("%s: %s is required", prefix, "class_name")
+		}
+	case "":
+		return fmt.Errorf("%s: %s is required", prefix, "type")
+	default:
+		return fmt.Errorf("%s: unknown type '%s'", prefix, d.Type)
+	}
+	return nil
+}
+
+// Load reads scorer definitions from a file and builds them through the
+// BuiltIn, PromptScorer, TracePromptScorer and CustomScorer factories, in
+// file order. Prompt scorers must already exist on the platform, and loading
+// fails if their settings differ from the file.
+func (f *ScorersFactory) Load(ctx context.Context, params ScorerLoadParams) ([]BaseScorer, error) {
+	var data []byte
+	var err error
+	if params.FS != nil {
+		data, err = fs.ReadFile(params.FS, params.Path)
+	} else {
+		data, err = os.ReadFile(params.Path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scorer definitions: %w", err)
+	}
+
+	defs, err := ParseScorerDefinitions(data)
+	if err != nil {
+		return nil, err
+	}
+	return f.FromDefinitions(ctx, defs)
+}
+
+// FromDefinitions validates and builds scorers from already-parsed
+// definitions. See Load for how prompt scorers are checked.
+func (f *ScorersFactory) FromDefinitions(ctx context.Context, defs []ScorerDefinition) ([]BaseScorer, error) {
+	scorers := make([]BaseScorer, 0, len(defs))
+	for i, def := range defs {
+		if err := def.validate(i); err != nil {
+			return nil, err
+		}
+		scorer, err := f.fromDefinition(ctx, def)
+		if err != nil {
+			return nil, err
+		}
+		scorers = append(scorers, scorer)
+	}
+	return scorers, nil
+}
+
+func (f *ScorersFactory) fromDefinition(ctx context.Context, def ScorerDefinition) (BaseScorer, error) {
+	switch def.Type {
+	case ScorerDefinitionTypeBuiltIn:
+		var name *string
+		if def.Name != "" {
+			name = String(def.Name)
+		}
+		scorer, err := f.BuiltIn.New(APIScorerType(def.ScoreType), BuiltInParams{
+			Name:           name,
+			Threshold:      def.Threshold,
+			Model:          def.Model,
+			StrictMode:     def.StrictMode,
+			Kwargs:         def.Kwargs,
+			RequiredParams: def.RequiredParams,
+			ResultType:     def.ResultType,
+			Options:        def.Options,
+			Range:          def.Range,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return scorer, nil
+	case ScorerDefinitionTypePrompt:
+		return f.promptScorerFromDefinition(ctx, f.PromptScorer, def)
+	case ScorerDefinitionTypeTracePrompt:
+		return f.promptScorerFromDefinition(ctx, f.TracePromptScorer, def)
+	case ScorerDefinitionTypeCustom:
+		scorer, err := f.CustomScorer.Get(ctx, def.Name, def.ClassName)
+		if err != nil {
+			return nil, err
+		}
+		if def.Range != nil {
+			if err := scorer.SetScoreRange(*def.Range); err != nil {
+				return nil, err
+			}
+		}
+		return scorer, nil
+	default:
+		return nil, fmt.Errorf("scorer '%s' has unknown type '%s'", def.Name, def.Type)
+	}
+}
+
+// promptScorerFromDefinition fetches the prompt scorer and checks it against
+// the definition, so a file never silently describes a different scorer than
+// the one that runs.
+func (f *ScorersFactory) promptScorerFromDefinition(ctx context.Context, factory *PromptScorerFactory, def ScorerDefinition) (*PromptScorer, error) {
+	scorer, err := factory.Get(ctx, def.Name)
+	if err != nil {
+		return nil, err
+	}
+	if fields := def.promptScorerDiff(scorer); len(fields) > 0 {
+		return nil, fmt.Errorf("prompt scorer '%s' on the platform differs from its definition in %s", def.Name, strings.Join(fields, ", "))
+	}
+	return scorer, nil
+}
+
+// promptScorerDiff lists the fields of the definition that differ from the
+// stored scorer. Fields left unset in the definition are not compared.
+func (d *ScorerDefinition) promptScorerDiff(scorer *PromptScorer) []string {
+	var fields []string
+	if d.Prompt != nil && *d.Prompt != scorer.prompt {
+		fields = append(fields, "prompt")
+	}
+	if d.Threshold != nil && *d.Threshold != scorer.threshold {
+		fields = append(fields, "threshold")
+	}
+	if d.Model != nil && *d.Model != scorer.model {
+		fields = append(fields, "model")
+	}
+	if d.Description != nil && *d.Description != scorer.description {
+		fields = append(fields, "description")
+	}
+	if d.ResultType != nil && *d.ResultType != scorer.resultType {
+		fields = append(fields, "result_type")
+	}
+	if d.Options != nil && !maps.Equal(d.Options, scorer.options) {
+		fields = append(fields, "options")
+	}
+	if d.Range != nil && *d.Range != scorer.scoreRange {
+		fields = append(fields, "range")
+	}
+	return fields
+}
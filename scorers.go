@@ -10,6 +10,7 @@ type ScorersFactory struct {
 	PromptScorer      *PromptScorerFactory
 	TracePromptScorer *PromptScorerFactory
 	CustomScorer      *CustomScorerFactory
+	Local             *LocalScorersFactory
 }
 
 func newScorersFactory(client *api.Client, projectName string, projectID string) *ScorersFactory {
@@ -18,8 +19,9 @@ func newScorersFactory(client *api.Client, projectName string, projectID string)
 		projectName:       projectName,
 		projectID:         projectID,
 		BuiltIn:           &BuiltInScorersFactory{},
-		PromptScorer:      &PromptScorerFactory{client: client, projectName: projectName, projectID: projectID, isTrace: false},
-		TracePromptScorer: &PromptScorerFactory{client: client, projectName: projectName, projectID: projectID, isTrace: true},
-		CustomScorer:      &CustomScorerFactory{},
+		PromptScorer:      newPromptScorerFactory(client, projectName, projectID, false),
+		TracePromptScorer: newPromptScorerFactory(client, projectName, projectID, true),
+		CustomScorer:      &CustomScorerFactory{client: client, projectName: projectName, projectID: projectID},
+		Local:             &LocalScorersFactory{},
 	}
 }
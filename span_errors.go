@@ -0,0 +1,87 @@
+package judgeval
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SetError marks the span as failed and records err as an exception event
+// with its type, message, the current stack trace and the chain of wrapped
+// errors. A nil err is ignored.
+func (b *BaseTracer) SetError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	recordException(span, fmt.Sprintf("%T", err), err.Error(), errorChain(err), false)
+}
+
+// RecordPanic marks the span as failed and records a recovered panic value,
+// including the panicking goroutine's stack when called from the deferred
+// function that recovered it. It does not re-panic.
+func (b *BaseTracer) RecordPanic(span trace.Span, recovered any) {
+	if recovered == nil {
+		return
+	}
+	var chain []string
+	if err, ok := recovered.(error); ok {
+		chain = errorChain(err)
+	}
+	recordException(span, fmt.Sprintf("%T", recovered), fmt.Sprint(recovered), chain, true)
+}
+
+// endSpanOnPanic records a panic with tracer.RecordPanic, ends span and
+// re-raises the panic. It must be deferred directly, or recover returns nil.
+// Ending the span first keeps span.End from recording the panic again.
+func endSpanOnPanic(tracer interface{ RecordPanic(trace.Span, any) }, span trace.Span) {
+	if r := recover(); r != nil {
+		tracer.RecordPanic(span, r)
+		span.End()
+		panic(r)
+	}
+}
+
+func recordException(span trace.Span, errType, message string, chain []string, panicked bool) {
+	attrs := []attribute.KeyValue{
+		attribute.String(AttributeKeysExceptionType, errType),
+		attribute.String(AttributeKeysExceptionMessage, message),
+		attribute.String(AttributeKeysExceptionStacktrace, string(debug.Stack())),
+	}
+	if len(chain) > 1 {
+		attrs = append(attrs, attribute.StringSlice(AttributeKeysJudgmentErrorChain, chain))
+	}
+	if panicked {
+		attrs = append(attrs, attribute.Bool(AttributeKeysExceptionEscaped, true))
+	}
+
+	span.AddEvent("exception", trace.WithAttributes(attrs...))
+	span.SetAttributes(attribute.String(AttributeKeysErrorType, errType))
+	span.SetStatus(codes.Error, message)
+}
+
+// errorChain lists each error reachable through Unwrap, depth-first, as
+// "type: message", starting with err itself.
+func errorChain(err error) []string {
+	var chain []string
+	var walk func(error)
+	walk = func(e error) {
+		if e == nil {
+			return
+		}
+		chain = append(chain, fmt.Sprintf("%T: %s", e, e.Error()))
+		switch u := e.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range u.Unwrap() {
+				walk(inner)
+			}
+		default:
+			walk(errors.Unwrap(e))
+		}
+	}
+	walk(err)
+	return chain
+}
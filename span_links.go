@@ -0,0 +1,81 @@
+package judgeval
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	LinkTypeFanIn       = "fan_in"
+	LinkTypeFollowsFrom = "follows_from"
+)
+
+// FanInLinks links an aggregation span to the parallel worker spans whose
+// results it consumes. Collect each worker's span context with
+// trace.SpanContextFromContext inside the worker. Invalid span contexts are
+// skipped.
+func FanInLinks(workers ...trace.SpanContext) trace.SpanStartOption {
+	links := make([]trace.Link, 0, len(workers))
+	for _, worker := range workers {
+		if !worker.IsValid() {
+			continue
+		}
+		links = append(links, trace.Link{
+			SpanContext: worker,
+			Attributes:  []attribute.KeyValue{attribute.String(AttributeKeysJudgmentLinkType, LinkTypeFanIn)},
+		})
+	}
+	return trace.WithLinks(links...)
+}
+
+// StartFanIn starts an aggregation span linked to the worker spans it
+// consumes, recording the number of workers linked.
+func (b *BaseTracer) StartFanIn(ctx context.Context, spanName string, workers []trace.SpanContext, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	count := 0
+	for _, worker := range workers {
+		if worker.IsValid() {
+			count++
+		}
+	}
+	opts = append(opts,
+		FanInLinks(workers...),
+		trace.WithAttributes(attribute.Int(AttributeKeysJudgmentFanInCount, count)),
+	)
+	return b.Span(ctx, spanName, opts...)
+}
+
+// FollowsFrom links a span to one that caused it in another trace, such as a
+// follow-up request to the conversation turn it continues. It is a no-op for
+// an invalid span context.
+func FollowsFrom(origin trace.SpanContext) trace.SpanStartOption {
+	if !origin.IsValid() {
+		return trace.WithLinks()
+	}
+	return trace.WithLinks(trace.Link{
+		SpanContext: origin,
+		Attributes:  []attribute.KeyValue{attribute.String(AttributeKeysJudgmentLinkType, LinkTypeFollowsFrom)},
+	})
+}
+
+// SpanContextFromIDs rebuilds the span context of a span recorded earlier
+// from its hex-encoded trace and span IDs, for use with FollowsFrom when
+// only the IDs were persisted.
+func SpanContextFromIDs(traceID, spanID string) (trace.SpanContext, error) {
+	tid, err := trace.TraceIDFromHex(traceID)
+	if err != nil {
+		return trace.SpanContext{}, fmt.Errorf("invalid trace ID %q: %w", traceID, err)
+	}
+	sid, err := trace.SpanIDFromHex(spanID)
+	if err != nil {
+		return trace.SpanContext{}, fmt.Errorf("invalid span ID %q: %w", spanID, err)
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}), nil
+}
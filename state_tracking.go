@@ -0,0 +1,141 @@
+package judgeval
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/JudgmentLabs/judgeval-go/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StateFinalizer records the state after a step. It is returned by
+// TrackState and should be called once the step has finished mutating it.
+type StateFinalizer func(after any)
+
+// TrackState records before as the span's state_before right away, so later
+// mutations of shared state do not leak into it, and returns a finalizer that
+// records state_after. Unless disabled on the tracer, the finalizer also
+// stores a structural diff of the two states as a list of JSON Patch style
+// operations.
+func (b *BaseTracer) TrackState(ctx context.Context, span trace.Span, before any) StateFinalizer {
+	serializedBefore, err := b.serializer(before)
+	if err != nil {
+		logger.Warning("Failed to serialize state before: %v", err)
+	} else {
+		span.SetAttributes(attribute.String(AttributeKeysJudgmentStateBefore, serializedBefore))
+	}
+
+	return func(after any) {
+		serializedAfter, err := b.serializer(after)
+		if err != nil {
+			logger.Warning("Failed to serialize state after: %v", err)
+			return
+		}
+		span.SetAttributes(attribute.String(AttributeKeysJudgmentStateAfter, serializedAfter))
+
+		if !b.recordStateDiff || serializedBefore == "" {
+			return
+		}
+		ops, ok := jsonDiff(serializedBefore, serializedAfter)
+		if !ok {
+			return
+		}
+		diff, err := json.Marshal(ops)
+		if err != nil {
+			return
+		}
+		span.SetAttributes(attribute.String(AttributeKeysJudgmentStateDiff, string(diff)))
+	}
+}
+
+type stateDiffOp struct {
+	Op    string
+	Path  string
+	Value any
+	Old   any
+}
+
+// MarshalJSON always emits value for add and replace and old for remove and
+// replace, so a JSON null on either side is kept rather than dropped.
+func (o stateDiffOp) MarshalJSON() ([]byte, error) {
+	type op struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value *any   `json:"value,omitempty"`
+		Old   *any   `json:"old,omitempty"`
+	}
+	out := op{Op: o.Op, Path: o.Path}
+	if o.Op == "add" || o.Op == "replace" {
+		out.Value = &o.Value
+	}
+	if o.Op == "remove" || o.Op == "replace" {
+		out.Old = &o.Old
+	}
+	return json.Marshal(out)
+}
+
+// jsonDiff compares two JSON documents structurally. It reports false when
+// either side is not valid JSON, as with custom serializers.
+func jsonDiff(before, after string) ([]stateDiffOp, bool) {
+	var a, b any
+	if json.Unmarshal([]byte(before), &a) != nil || json.Unmarshal([]byte(after), &b) != nil {
+		return nil, false
+	}
+	ops := []stateDiffOp{}
+	diffValues("", a, b, &ops)
+	return ops, true
+}
+
+func diffValues(path string, a, b any, ops *[]stateDiffOp) {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := slices.Sorted(maps.Keys(av))
+		for _, k := range keys {
+			child := path + "/" + escapeJSONPointer(k)
+			if next, ok := bv[k]; ok {
+				diffValues(child, av[k], next, ops)
+			} else {
+				*ops = append(*ops, stateDiffOp{Op: "remove", Path: child, Old: av[k]})
+			}
+		}
+		for _, k := range slices.Sorted(maps.Keys(bv)) {
+			if _, ok := av[k]; !ok {
+				*ops = append(*ops, stateDiffOp{Op: "add", Path: path + "/" + escapeJSONPointer(k), Value: bv[k]})
+			}
+		}
+		return
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < min(len(av), len(bv)); i++ {
+			diffValues(path+"/"+strconv.Itoa(i), av[i], bv[i], ops)
+		}
+		for i := len(av) - 1; i >= len(bv); i-- {
+			*ops = append(*ops, stateDiffOp{Op: "remove", Path: path + "/" + strconv.Itoa(i), Old: av[i]})
+		}
+		for i := len(av); i < len(bv); i++ {
+			*ops = append(*ops, stateDiffOp{Op: "add", Path: path + "/" + strconv.Itoa(i), Value: bv[i]})
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*ops = append(*ops, stateDiffOp{Op: "replace", Path: path, Value: b, Old: a})
+	}
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,55 @@
+package judgeval
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{name: "equal", before: `{"a":[1,{"b":2}]}`, after: `{"a":[1,{"b":2}]}`, want: `[]`},
+		{name: "root scalar", before: `1`, after: `2`, want: `[{"op":"replace","path":"","value":2,"old":1}]`},
+		{
+			name:   "nested object",
+			before: `{"a":1,"b":{"c":2},"d":3}`,
+			after:  `{"a":1,"b":{"c":4},"e":5}`,
+			want:   `[{"op":"replace","path":"/b/c","value":4,"old":2},{"op":"remove","path":"/d","old":3},{"op":"add","path":"/e","value":5}]`,
+		},
+		{name: "array shrinks from the end", before: `[1,2,3]`, after: `[1]`, want: `[{"op":"remove","path":"/2","old":3},{"op":"remove","path":"/1","old":2}]`},
+		{name: "array grows", before: `[1]`, after: `[1,"x"]`, want: `[{"op":"add","path":"/1","value":"x"}]`},
+		{name: "array element changes", before: `{"items":[{"n":1}]}`, after: `{"items":[{"n":2}]}`, want: `[{"op":"replace","path":"/items/0/n","value":2,"old":1}]`},
+		{name: "type change", before: `{"a":[1]}`, after: `{"a":{"x":1}}`, want: `[{"op":"replace","path":"/a","value":{"x":1},"old":[1]}]`},
+		{name: "set to null", before: `{"a":1}`, after: `{"a":null}`, want: `[{"op":"replace","path":"/a","value":null,"old":1}]`},
+		{name: "null added and removed", before: `{"a":null}`, after: `{"b":null}`, want: `[{"op":"remove","path":"/a","old":null},{"op":"add","path":"/b","value":null}]`},
+		{name: "escaped keys", before: `{"a/b~c":1}`, after: `{}`, want: `[{"op":"remove","path":"/a~1b~0c","old":1}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, ok := jsonDiff(tt.before, tt.after)
+			if !ok {
+				t.Fatalf("jsonDiff(%s, %s) reported invalid JSON", tt.before, tt.after)
+			}
+			got, err := json.Marshal(ops)
+			if err != nil {
+				t.Fatalf("failed to marshal ops: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("jsonDiff(%s, %s) = %s, want %s", tt.before, tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONDiffInvalid(t *testing.T) {
+	for _, pair := range [][2]string{{`not json`, `{}`}, {`{}`, `<state>`}} {
+		if _, ok := jsonDiff(pair[0], pair[1]); ok {
+			t.Errorf("jsonDiff(%q, %q) reported valid JSON", pair[0], pair[1])
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package judgeval
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+func tokenize(s string) []string {
+	return strings.Fields(normalizeText(s))
+}
+
+func normalizeText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			continue
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// levenshteinSimilarity maps edit distance onto [0, 1], where 1 means the
+// strings are identical.
+func levenshteinSimilarity(a, b string) float64 {
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshteinDistance(a, b))/float64(longest)
+}
+
+func longestCommonSubsequence(a, b []string) int {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				curr[j] = prev[j-1] + 1
+			} else {
+				curr[j] = max(prev[j], curr[j-1])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// rougeL returns the ROUGE-L precision, recall and F1 of candidate against
+// reference, computed over normalized word tokens.
+func rougeL(candidate, reference string) (precision, recall, f1 float64) {
+	cand, ref := tokenize(candidate), tokenize(reference)
+	lcs := longestCommonSubsequence(cand, ref)
+	if lcs == 0 {
+		return 0, 0, 0
+	}
+
+	precision = float64(lcs) / float64(len(cand))
+	recall = float64(lcs) / float64(len(ref))
+	f1 = 2 * precision * recall / (precision + recall)
+	return precision, recall, f1
+}
+
+// bleu returns the sentence-level BLEU score of candidate against reference
+// using n-grams up to maxN, add-one smoothing for n > 1, and the standard
+// brevity penalty.
+func bleu(candidate, reference string, maxN int) float64 {
+	cand, ref := tokenize(candidate), tokenize(reference)
+	if len(cand) == 0 || len(ref) == 0 {
+		return 0
+	}
+
+	logSum := 0.0
+	for n := 1; n <= maxN; n++ {
+		candCounts := ngramCounts(cand, n)
+		refCounts := ngramCounts(ref, n)
+
+		matches, total := 0, 0
+		for gram, count := range candCounts {
+			matches += min(count, refCounts[gram])
+			total += count
+		}
+
+		if n == 1 {
+			if matches == 0 {
+				return 0
+			}
+			logSum += math.Log(float64(matches) / float64(total))
+			continue
+		}
+		logSum += math.Log(float64(matches+1) / float64(total+1))
+	}
+
+	brevityPenalty := 1.0
+	if len(cand) < len(ref) {
+		brevityPenalty = math.Exp(1 - float64(len(ref))/float64(len(cand)))
+	}
+	return brevityPenalty * math.Exp(logSum/float64(maxN))
+}
+
+func ngramCounts(tokens []string, n int) map[string]int {
+	counts := make(map[string]int)
+	for i := 0; i+n <= len(tokens); i++ {
+		counts[strings.Join(tokens[i:i+n], "\x00")]++
+	}
+	return counts
+}
@@ -0,0 +1,82 @@
+package judgeval
+
+import (
+	"math"
+	"testing"
+)
+
+const metricTolerance = 1e-9
+
+func TestBLEU(t *testing.T) {
+	tests := []struct {
+		name      string
+		candidate string
+		reference string
+		maxN      int
+		want      float64
+	}{
+		{name: "identical", candidate: "the cat sat on the mat", reference: "the cat sat on the mat", maxN: 4, want: 1},
+		{name: "normalizes case and punctuation", candidate: "The cat, sat!", reference: "the cat sat", maxN: 2, want: 1},
+		{name: "no unigram overlap", candidate: "dog runs", reference: "cat sleeps", maxN: 2, want: 0},
+		{name: "empty candidate", candidate: "", reference: "the cat", maxN: 2, want: 0},
+		{name: "empty reference", candidate: "the cat", reference: "", maxN: 2, want: 0},
+		{name: "brevity penalty", candidate: "the cat sat", reference: "the cat sat on the mat", maxN: 1, want: math.Exp(-1)},
+		{name: "smoothed bigrams", candidate: "a b", reference: "a c", maxN: 2, want: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bleu(tt.candidate, tt.reference, tt.maxN)
+			if math.Abs(got-tt.want) > metricTolerance {
+				t.Errorf("bleu(%q, %q, %d) = %v, want %v", tt.candidate, tt.reference, tt.maxN, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRougeL(t *testing.T) {
+	tests := []struct {
+		name          string
+		candidate     string
+		reference     string
+		wantPrecision float64
+		wantRecall    float64
+		wantF1        float64
+	}{
+		{name: "identical", candidate: "the cat sat", reference: "the cat sat", wantPrecision: 1, wantRecall: 1, wantF1: 1},
+		{name: "prefix of reference", candidate: "the cat sat", reference: "the cat sat on the mat", wantPrecision: 1, wantRecall: 0.5, wantF1: 2.0 / 3},
+		{name: "subsequence with gaps", candidate: "the big cat sat down", reference: "the cat sat", wantPrecision: 0.6, wantRecall: 1, wantF1: 0.75},
+		{name: "no overlap", candidate: "dog runs", reference: "cat sleeps"},
+		{name: "empty candidate", candidate: "", reference: "cat"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			precision, recall, f1 := rougeL(tt.candidate, tt.reference)
+			if math.Abs(precision-tt.wantPrecision) > metricTolerance ||
+				math.Abs(recall-tt.wantRecall) > metricTolerance ||
+				math.Abs(f1-tt.wantF1) > metricTolerance {
+				t.Errorf("rougeL(%q, %q) = (%v, %v, %v), want (%v, %v, %v)",
+					tt.candidate, tt.reference, precision, recall, f1, tt.wantPrecision, tt.wantRecall, tt.wantF1)
+			}
+		})
+	}
+}
+
+func TestLevenshteinSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{a: "", b: "", want: 1},
+		{a: "kitten", b: "kitten", want: 1},
+		{a: "kitten", b: "sitting", want: 1 - 3.0/7},
+		{a: "abc", b: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := levenshteinSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > metricTolerance {
+			t.Errorf("levenshteinSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
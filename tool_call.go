@@ -0,0 +1,147 @@
+package judgeval
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/logger"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const toolCallIndexSize = 1024
+
+type toolCallConfig struct {
+	callID string
+	schema any
+}
+
+type ToolCallOption interface {
+	apply(*toolCallConfig)
+}
+
+type toolCallOptionFunc func(*toolCallConfig)
+
+func (f toolCallOptionFunc) apply(c *toolCallConfig) {
+	f(c)
+}
+
+// WithToolCallID sets the ID the LLM gave this tool call. When the LLM span
+// that requested it was recorded by one of the integrations, the tool span is
+// linked to it.
+func WithToolCallID(id string) ToolCallOption {
+	return toolCallOptionFunc(func(c *toolCallConfig) {
+		c.callID = id
+	})
+}
+
+// WithToolSchema records the JSON schema of the tool's arguments. It accepts
+// anything that marshals to a JSON schema object.
+func WithToolSchema(schema any) ToolCallOption {
+	return toolCallOptionFunc(func(c *toolCallConfig) {
+		c.schema = schema
+	})
+}
+
+// ToolCall runs fn inside a tool span named toolName, recording the tool
+// name, arguments, result or error, and latency as structured attributes
+// alongside the usual input and output. A panic is recorded before being
+// re-raised.
+func (b *BaseTracer) ToolCall(ctx context.Context, toolName string, args any, fn func(ctx context.Context) (any, error), opts ...ToolCallOption) (any, error) {
+	cfg := &toolCallConfig{}
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
+	var startOpts []trace.SpanStartOption
+	if cfg.callID != "" && b.toolCalls != nil {
+		if llmSpan, ok := b.toolCalls.lookup(cfg.callID); ok {
+			startOpts = append(startOpts, trace.WithLinks(trace.Link{SpanContext: llmSpan}))
+		}
+	}
+
+	ctx, span := b.Span(ctx, toolName, startOpts...)
+	defer span.End()
+
+	b.SetToolSpan(span)
+	span.SetAttributes(attribute.String(AttributeKeysGenAIToolName, toolName))
+	if cfg.callID != "" {
+		span.SetAttributes(attribute.String(AttributeKeysGenAIToolCallID, cfg.callID))
+	}
+	if cfg.schema != nil {
+		if schema, err := normalizeJSONSchema(cfg.schema); err != nil {
+			logger.Warning("Ignoring schema for tool %s: %v", toolName, err)
+		} else {
+			b.SetAttribute(span, AttributeKeysJudgmentToolSchema, schema)
+		}
+	}
+	b.SetAttribute(span, AttributeKeysGenAIToolCallArguments, args)
+	b.SetInput(span, args)
+	defer endSpanOnPanic(b, span)
+
+	start := time.Now()
+	result, err := fn(ctx)
+	span.SetAttributes(attribute.Float64(AttributeKeysJudgmentToolLatencyMs, float64(time.Since(start).Microseconds())/1000))
+	if err != nil {
+		b.SetError(span, err)
+		return result, err
+	}
+
+	b.SetAttribute(span, AttributeKeysGenAIToolCallResult, result)
+	b.SetOutput(span, result)
+	return result, nil
+}
+
+// toolCallIndex remembers which LLM span requested each recent tool call, as
+// reported by the integrations in gen_ai.response.tool_call_ids, so ToolCall
+// can link back to it. The oldest entries are evicted first.
+type toolCallIndex struct {
+	mu    sync.Mutex
+	spans map[string]trace.SpanContext
+	order []string
+}
+
+func newToolCallIndex() *toolCallIndex {
+	return &toolCallIndex{spans: make(map[string]trace.SpanContext)}
+}
+
+func (x *toolCallIndex) lookup(callID string) (trace.SpanContext, bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	spanContext, ok := x.spans[callID]
+	return spanContext, ok
+}
+
+func (x *toolCallIndex) ForceFlush(ctx context.Context) error { return nil }
+func (x *toolCallIndex) Shutdown(ctx context.Context) error   { return nil }
+
+func (x *toolCallIndex) OnStart(parentContext context.Context, span sdktrace.ReadWriteSpan) {
+}
+
+func (x *toolCallIndex) OnEnd(s sdktrace.ReadOnlySpan) {
+	var callIDs []string
+	for _, attr := range s.Attributes() {
+		if attr.Key == AttributeKeysGenAIResponseToolCallIDs {
+			callIDs = attr.Value.AsStringSlice()
+			break
+		}
+	}
+	if len(callIDs) == 0 {
+		return
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for _, id := range callIDs {
+		if _, ok := x.spans[id]; !ok {
+			x.order = append(x.order, id)
+		}
+		x.spans[id] = s.SpanContext()
+	}
+	for len(x.order) > toolCallIndexSize {
+		delete(x.spans, x.order[0])
+		x.order = x.order[1:]
+	}
+}
@@ -0,0 +1,274 @@
+package judgeval
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api"
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+	"github.com/JudgmentLabs/judgeval-go/logger"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceTagMaxAttempts     = 3
+	traceTagRetryBackoff    = 500 * time.Millisecond
+	traceTagExportedHistory = 4096
+	traceTagPendingTTL      = 2 * time.Minute
+	traceTagPendingLimit    = 4096
+)
+
+// traceTagger sends trace tags to the API. Tags are held until one of the
+// trace's spans has been exported, since the API cannot tag a trace it has
+// not received yet. Tags held longer than pendingTTL, or beyond
+// pendingLimit traces, are sent anyway and rely on the send retries. The
+// most recently exported traces are remembered so later tags for them are
+// sent right away. In offline mode the tags are written next to the spans
+// instead and sent by UploadOfflineSpans.
+type traceTagger struct {
+	apiClient    *api.Client
+	projectID    string
+	offline      *FileSpanExporter
+	pendingTTL   time.Duration
+	pendingLimit int
+
+	mu            sync.Mutex
+	pending       map[trace.TraceID]*pendingTraceTags
+	pendingOrder  []trace.TraceID
+	exported      map[trace.TraceID]struct{}
+	exportedOrder []trace.TraceID
+	wg            sync.WaitGroup
+}
+
+type pendingTraceTags struct {
+	tags     []string
+	heldFrom time.Time
+}
+
+func newTraceTagger(apiClient *api.Client, projectID string) *traceTagger {
+	return &traceTagger{
+		apiClient:    apiClient,
+		projectID:    projectID,
+		pendingTTL:   traceTagPendingTTL,
+		pendingLimit: traceTagPendingLimit,
+		pending:      make(map[trace.TraceID]*pendingTraceTags),
+		exported:     make(map[trace.TraceID]struct{}),
+	}
+}
+
+// TagTrace tags the trace of the span in ctx. The tags are sent once the
+// trace has been exported, or right away if it already has been. Failed
+// requests are retried in the background.
+func (b *BaseTracer) TagTrace(ctx context.Context, tags ...string) {
+	if len(tags) == 0 {
+		return
+	}
+	if b.tagger == nil {
+		logger.Warning("Tracer not initialized, skipping trace tags")
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	spanContext := span.SpanContext()
+	if !spanContext.IsValid() {
+		logger.Warning("No active span in context, skipping trace tags")
+		return
+	}
+	if !spanContext.IsSampled() {
+		return
+	}
+
+	b.tagger.tag(spanContext.TraceID(), tags)
+}
+
+// TagTraceByID tags a trace by its hex-encoded ID, retrying failed requests.
+// Use it to tag traces from outside the request that produced them.
+func (b *BaseTracer) TagTraceByID(ctx context.Context, traceID string, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	if b.tagger == nil {
+		return fmt.Errorf("failed to tag trace %s: tracer not initialized", traceID)
+	}
+	if _, err := trace.TraceIDFromHex(traceID); err != nil {
+		return fmt.Errorf("failed to tag trace %s: %w", traceID, err)
+	}
+	if b.tagger.offline != nil {
+		return b.tagger.offline.writeTraceTags(traceID, tags)
+	}
+	return b.tagger.send(ctx, traceID, tags)
+}
+
+// tag sends tags if the trace is known to have been exported, and holds
+// them for onExport otherwise.
+func (t *traceTagger) tag(traceID trace.TraceID, tags []string) {
+	t.mu.Lock()
+	if _, ok := t.exported[traceID]; ok {
+		t.mu.Unlock()
+		t.deliver(traceID.String(), tags)
+		return
+	}
+
+	held, ok := t.pending[traceID]
+	if !ok {
+		held = &pendingTraceTags{heldFrom: time.Now()}
+		t.pending[traceID] = held
+		t.pendingOrder = append(t.pendingOrder, traceID)
+	}
+	for _, tag := range tags {
+		if !slices.Contains(held.tags, tag) {
+			held.tags = append(held.tags, tag)
+		}
+	}
+	overdue := t.takeOverdue(time.Now())
+	t.mu.Unlock()
+
+	t.deliverAll(overdue)
+}
+
+// onExport records every trace that has a span in spans as exported and
+// sends the tags held for them. Tags of a failed batch stay held until they
+// are overdue.
+func (t *traceTagger) onExport(ctx context.Context, spans []sdktrace.ReadOnlySpan, err error) {
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	ready := make(map[trace.TraceID][]string)
+	for _, s := range spans {
+		traceID := s.SpanContext().TraceID()
+		t.markExported(traceID)
+		if held, ok := t.pending[traceID]; ok {
+			ready[traceID] = held.tags
+			delete(t.pending, traceID)
+		}
+	}
+	for traceID, tags := range t.takeOverdue(time.Now()) {
+		ready[traceID] = tags
+	}
+	t.mu.Unlock()
+
+	t.deliverAll(ready)
+}
+
+// takeOverdue removes and returns the tags held longer than pendingTTL, and
+// the oldest ones beyond pendingLimit. Callers must hold t.mu.
+func (t *traceTagger) takeOverdue(now time.Time) map[trace.TraceID][]string {
+	// pendingOrder is in the order tags were first held, so overdue traces
+	// are always at its front. Traces already sent by onExport are skipped.
+	overdue := make(map[trace.TraceID][]string)
+	for len(t.pendingOrder) > 0 {
+		traceID := t.pendingOrder[0]
+		held, ok := t.pending[traceID]
+		if ok && now.Sub(held.heldFrom) < t.pendingTTL && len(t.pending) <= t.pendingLimit {
+			break
+		}
+		t.pendingOrder = t.pendingOrder[1:]
+		if ok {
+			overdue[traceID] = held.tags
+			delete(t.pending, traceID)
+		}
+	}
+	if len(overdue) > 0 {
+		logger.Debug("Sending tags for %d traces that were not exported in time", len(overdue))
+	}
+	return overdue
+}
+
+func (t *traceTagger) markExported(traceID trace.TraceID) {
+	if _, ok := t.exported[traceID]; ok {
+		return
+	}
+	t.exported[traceID] = struct{}{}
+	t.exportedOrder = append(t.exportedOrder, traceID)
+	for len(t.exportedOrder) > traceTagExportedHistory {
+		delete(t.exported, t.exportedOrder[0])
+		t.exportedOrder = t.exportedOrder[1:]
+	}
+}
+
+func (t *traceTagger) deliverAll(tags map[trace.TraceID][]string) {
+	for traceID, traceTags := range tags {
+		t.deliver(traceID.String(), traceTags)
+	}
+}
+
+func (t *traceTagger) deliver(traceID string, tags []string) {
+	if t.offline == nil {
+		t.sendAsync(traceID, tags)
+		return
+	}
+	if err := t.offline.writeTraceTags(traceID, tags); err != nil {
+		logger.Error("Failed to store tags for trace %s: %v", traceID, err)
+	}
+}
+
+func (t *traceTagger) sendAsync(traceID string, tags []string) {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		if err := t.send(context.Background(), traceID, tags); err != nil {
+			logger.Error("Failed to tag trace: %v", err)
+		}
+	}()
+}
+
+func (t *traceTagger) send(ctx context.Context, traceID string, tags []string) error {
+	payload := &models.AddTraceTagsRequest{Tags: tags}
+
+	var err error
+	for attempt := 1; attempt <= traceTagMaxAttempts; attempt++ {
+		if _, err = t.apiClient.PostProjectsTracesByTraceIdTags(t.projectID, traceID, payload); err == nil {
+			return nil
+		}
+		if attempt == traceTagMaxAttempts {
+			break
+		}
+		logger.Debug("Tagging trace %s failed (attempt %d/%d): %v", traceID, attempt, traceTagMaxAttempts, err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to tag trace %s: %w", traceID, ctx.Err())
+		case <-time.After(traceTagRetryBackoff << (attempt - 1)):
+		}
+	}
+	return fmt.Errorf("failed to tag trace %s after %d attempts: %w", traceID, traceTagMaxAttempts, err)
+}
+
+// wait blocks until in-flight tag requests finish or ctx is done.
+func (t *traceTagger) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flushPending sends the tags of traces that were never exported, for use
+// at shutdown once nothing more will be exported.
+func (t *traceTagger) flushPending() {
+	t.mu.Lock()
+	pending := make(map[trace.TraceID][]string, len(t.pending))
+	for traceID, held := range t.pending {
+		pending[traceID] = held.tags
+	}
+	clear(t.pending)
+	t.pendingOrder = nil
+	t.mu.Unlock()
+
+	if len(pending) > 0 {
+		logger.Warning("Sending tags for %d traces that were never exported", len(pending))
+	}
+	t.deliverAll(pending)
+}
@@ -0,0 +1,145 @@
+package judgeval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JudgmentLabs/judgeval-go/internal/api"
+	"github.com/JudgmentLabs/judgeval-go/internal/api/models"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tagRecorder serves the trace tags endpoint and records the tags sent for
+// each trace. Requests fail with a 503 while failures is positive.
+type tagRecorder struct {
+	mu       sync.Mutex
+	tags     map[string][]string
+	failures atomic.Int32
+}
+
+func newTestTraceTagger(t *testing.T) (*traceTagger, *tagRecorder) {
+	t.Helper()
+	recorder := &tagRecorder{tags: make(map[string][]string)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/v1/projects/project/traces/"), "/tags")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if recorder.failures.Add(-1) >= 0 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var req models.AddTraceTagsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode tags: %v", err)
+		}
+		recorder.mu.Lock()
+		recorder.tags[traceID] = append(recorder.tags[traceID], req.Tags...)
+		recorder.mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	return newTraceTagger(api.NewClient(server.URL, "key", "org"), "project"), recorder
+}
+
+func (r *tagRecorder) sent(traceID trace.TraceID) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tags[traceID.String()]
+}
+
+func testTraceID(b byte) trace.TraceID {
+	return trace.TraceID{b}
+}
+
+func exportedSpan(traceID trace.TraceID) sdktrace.ReadOnlySpan {
+	return tracetest.SpanStub{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{1}}),
+	}.Snapshot()
+}
+
+func waitForTags(t *testing.T, tagger *traceTagger) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tagger.wait(ctx); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+}
+
+func TestTraceTaggerHoldsTagsUntilExport(t *testing.T) {
+	tagger, recorder := newTestTraceTagger(t)
+	traceID := testTraceID(1)
+
+	tagger.tag(traceID, []string{"a", "b"})
+	tagger.tag(traceID, []string{"b", "c"})
+	waitForTags(t, tagger)
+	if got := recorder.sent(traceID); len(got) != 0 {
+		t.Fatalf("tags sent before export: %v", got)
+	}
+
+	tagger.onExport(context.Background(), []sdktrace.ReadOnlySpan{exportedSpan(traceID)}, nil)
+	waitForTags(t, tagger)
+	if got := recorder.sent(traceID); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("tags after export = %v, want [a b c]", got)
+	}
+
+	tagger.tag(traceID, []string{"d"})
+	waitForTags(t, tagger)
+	if got := recorder.sent(traceID); !slices.Equal(got, []string{"a", "b", "c", "d"}) {
+		t.Errorf("tags for an exported trace = %v, want d sent right away", got)
+	}
+}
+
+func TestTraceTaggerSendsOverdueTags(t *testing.T) {
+	tagger, recorder := newTestTraceTagger(t)
+	tagger.pendingTTL = 10 * time.Millisecond
+	stale, fresh := testTraceID(1), testTraceID(2)
+
+	tagger.tag(stale, []string{"stale"})
+	time.Sleep(20 * time.Millisecond)
+	tagger.tag(fresh, []string{"fresh"})
+	waitForTags(t, tagger)
+
+	if got := recorder.sent(stale); !slices.Equal(got, []string{"stale"}) {
+		t.Errorf("tags for the expired trace = %v, want [stale]", got)
+	}
+	if got := recorder.sent(fresh); len(got) != 0 {
+		t.Errorf("tags for the fresh trace = %v, want them held", got)
+	}
+}
+
+func TestTraceTaggerSendsTagsBeyondLimit(t *testing.T) {
+	tagger, recorder := newTestTraceTagger(t)
+	tagger.pendingLimit = 1
+	recorder.failures.Store(1)
+	oldest, newest := testTraceID(1), testTraceID(2)
+
+	tagger.tag(oldest, []string{"oldest"})
+	tagger.tag(newest, []string{"newest"})
+	waitForTags(t, tagger)
+
+	if got := recorder.sent(oldest); !slices.Equal(got, []string{"oldest"}) {
+		t.Errorf("tags for the oldest trace = %v, want [oldest] after a retry", got)
+	}
+	if got := recorder.sent(newest); len(got) != 0 {
+		t.Errorf("tags for the newest trace = %v, want them held", got)
+	}
+
+	tagger.flushPending()
+	waitForTags(t, tagger)
+	if got := recorder.sent(newest); !slices.Equal(got, []string{"newest"}) {
+		t.Errorf("tags after flushPending = %v, want [newest]", got)
+	}
+}
@@ -3,6 +3,8 @@ package judgeval
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/JudgmentLabs/judgeval-go/internal/api"
 	"github.com/JudgmentLabs/judgeval-go/logger"
@@ -27,6 +29,21 @@ type TracerCreateParams struct {
 	ResourceAttributes map[string]any
 	FilterTracer       FilterTracerFunc
 	Initialize         *bool
+	// RecordStateDiff stores a structural diff alongside the states recorded
+	// by TrackState. Defaults to true.
+	RecordStateDiff *bool
+	// Pricing overrides or extends the built-in LLM pricing table used to
+	// compute span costs.
+	Pricing []ModelPricing
+	// SpanUpdateInterval, when set, exports a snapshot of every span that has
+	// been open longer than the interval, once per interval, so long-running
+	// traces show up before their root span ends. Disabled by default.
+	SpanUpdateInterval *time.Duration
+	// Offline writes spans to local files instead of exporting them.
+	Offline *OfflineParams
+	// Sampling limits which traces are recorded. All traces are kept by
+	// default.
+	Sampling *SamplingParams
 }
 
 func (f *TracerFactory) Create(ctx context.Context, params TracerCreateParams) (*Tracer, error) {
@@ -35,16 +52,28 @@ func (f *TracerFactory) Create(ctx context.Context, params TracerCreateParams) (
 		serializer = defaultJSONSerializer
 	}
 
+	sampler, err := newSampler(params.Sampling)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sampler: %w", err)
+	}
+
 	tracer := &Tracer{
 		BaseTracer: &BaseTracer{
-			projectName:      f.projectName,
-			projectID:        f.projectID,
-			enableEvaluation: getBool(params.EnableEvaluation, true),
-			apiClient:        f.client,
-			serializer:       serializer,
+			projectName:        f.projectName,
+			projectID:          f.projectID,
+			enableEvaluation:   getBool(params.EnableEvaluation, true),
+			apiClient:          f.client,
+			serializer:         serializer,
+			recordStateDiff:    getBool(params.RecordStateDiff, true),
+			pricing:            newPricingTable(params.Pricing),
+			spanUpdateInterval: getDuration(params.SpanUpdateInterval, 0),
+			tagger:             newTraceTagger(f.client, f.projectID),
+			offline:            params.Offline,
+			toolCalls:          newToolCallIndex(),
 		},
 		resourceAttributes: params.ResourceAttributes,
 		filterTracer:       params.FilterTracer,
+		sampler:            sampler,
 	}
 
 	if getBool(params.Initialize, true) {
@@ -63,6 +92,7 @@ type Tracer struct {
 	tracerProvider     *JudgmentTracerProvider
 	resourceAttributes map[string]any
 	filterTracer       FilterTracerFunc
+	sampler            sdktrace.Sampler
 }
 
 func (t *Tracer) Initialize(ctx context.Context) error {
@@ -122,12 +152,19 @@ func (t *Tracer) Initialize(ctx context.Context) error {
 		attrs...,
 	)
 
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSpanProcessor(t.getSpanProcessor(ctx)),
+	}
+	if t.sampler != nil {
+		providerOpts = append(providerOpts, sdktrace.WithSampler(t.sampler))
+	}
+
 	t.tracerProvider = NewJudgmentTracerProvider(
 		&JudgmentTracerProviderConfig{
 			FilterTracer: t.filterTracer,
 		},
-		sdktrace.WithResource(res),
-		sdktrace.WithSpanProcessor(t.getSpanProcessor(ctx)),
+		providerOpts...,
 	)
 
 	otel.SetTracerProvider(t.tracerProvider)
@@ -142,7 +179,10 @@ func (t *Tracer) ForceFlush(ctx context.Context) error {
 		logger.Warning("Tracer not initialized, skipping force flush")
 		return nil
 	}
-	return t.tracerProvider.ForceFlush(ctx)
+	if err := t.tracerProvider.ForceFlush(ctx); err != nil {
+		return err
+	}
+	return t.tagger.wait(ctx)
 }
 
 func (t *Tracer) Shutdown(ctx context.Context) error {
@@ -157,6 +197,19 @@ func (t *Tracer) Shutdown(ctx context.Context) error {
 		return err
 	}
 
+	t.tagger.flushPending()
+	if err := t.tagger.wait(ctx); err != nil {
+		logger.Error("Failed to send trace tags before shutdown: %v", err)
+		return err
+	}
+	if offline := t.tagger.offline; offline != nil {
+		// Tags flushed above may have reopened the offline file.
+		if err := offline.Shutdown(ctx); err != nil {
+			logger.Error("Failed to close offline file: %v", err)
+			return err
+		}
+	}
+
 	t.tracerProvider = nil
 	logger.Info("Tracer shut down successfully")
 	return nil
@@ -11,12 +11,46 @@ const (
 	APIScorerTypeAnswerRelevancy   APIScorerType = "Answer Relevancy"
 	APIScorerTypeAnswerCorrectness APIScorerType = "Answer Correctness"
 	APIScorerTypeCustom            APIScorerType = "Custom"
+
+	APIScorerTypeInstructionAdherence APIScorerType = "Instruction Adherence"
+	APIScorerTypeToolCorrectness      APIScorerType = "Tool Correctness"
+	APIScorerTypeHallucination        APIScorerType = "Hallucination"
+	APIScorerTypeGroundedness         APIScorerType = "Groundedness"
+	APIScorerTypeContextualPrecision  APIScorerType = "Contextual Precision"
+	APIScorerTypeContextualRecall     APIScorerType = "Contextual Recall"
+	APIScorerTypeContextualRelevancy  APIScorerType = "Contextual Relevancy"
+	APIScorerTypeSummarization        APIScorerType = "Summarization"
+	APIScorerTypeJSONCorrectness      APIScorerType = "JSON Correctness"
+	APIScorerTypeToolOrder            APIScorerType = "Tool Order"
+	APIScorerTypeToolDependency       APIScorerType = "Tool Dependency"
+	APIScorerTypeExecutionOrder       APIScorerType = "Execution Order"
+	APIScorerTypeDerailment           APIScorerType = "Derailment"
 )
 
 func (t APIScorerType) String() string {
 	return string(t)
 }
 
+type ScorerResultType string
+
+const (
+	ScorerResultTypeNumeric     ScorerResultType = "numeric"
+	ScorerResultTypeBinary      ScorerResultType = "binary"
+	ScorerResultTypeCategorical ScorerResultType = "categorical"
+)
+
+func (t ScorerResultType) String() string {
+	return string(t)
+}
+
+func (t ScorerResultType) valid() bool {
+	switch t {
+	case ScorerResultTypeNumeric, ScorerResultTypeBinary, ScorerResultTypeCategorical:
+		return true
+	}
+	return false
+}
+
 type ScorerConfig = models.ScorerConfig
 
 type SerializerFunc func(interface{}) (string, error)